@@ -21,6 +21,8 @@ type DmrClusterInfoModel struct {
 	Password                     types.String        `tfsdk:"password"`
 	RemoteAddress                types.String        `tfsdk:"remote_address"`
 	PrimaryRouterName            types.String        `tfsdk:"primary_router_name"`
+	BackupRouterName             types.String        `tfsdk:"backup_router_name"`
+	MonitoringRouterName         types.String        `tfsdk:"monitoring_router_name"`
 	SupportedAuthenticationModes basetypes.ListValue `tfsdk:"supported_authentication_modes"`
 }
 
@@ -61,6 +63,20 @@ func DmrClusterInfoAttributeSchema() schema.Attribute {
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"backup_router_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the backup router in the DMR cluster. Only set for high-availability services.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"monitoring_router_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the monitoring router in the DMR cluster. Only set for high-availability services.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"supported_authentication_modes": schema.ListAttribute{
 				MarkdownDescription: "The authentication mode between the nodes in the DMR cluster.",
 				ElementType:         types.StringType,
@@ -80,6 +96,8 @@ func DmrClusterInfoObjectType() types.ObjectType {
 			"password":                       types.StringType,
 			"remote_address":                 types.StringType,
 			"primary_router_name":            types.StringType,
+			"backup_router_name":             types.StringType,
+			"monitoring_router_name":         types.StringType,
 			"supported_authentication_modes": basetypes.ListType{ElemType: types.StringType},
 		},
 	}
@@ -93,6 +111,8 @@ func (m DmrClusterInfoModel) ToObjectValue() (basetypes.ObjectValue, diag.Diagno
 			"password":                       m.Password,
 			"remote_address":                 m.RemoteAddress,
 			"primary_router_name":            m.PrimaryRouterName,
+			"backup_router_name":             m.BackupRouterName,
+			"monitoring_router_name":         m.MonitoringRouterName,
 			"supported_authentication_modes": m.SupportedAuthenticationModes,
 		})
 }
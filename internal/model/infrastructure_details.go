@@ -0,0 +1,75 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Package model contains the InfrastructureDetails schema which is an object nested in the service resource.  It
+// describes the hostnames of the nodes the service runs on.  Standalone services only have a primary node, while
+// high-availability services also have a backup and a monitoring node.
+
+type InfrastructureDetailsModel struct {
+	PrimaryNodeHostname    types.String `tfsdk:"primary_node_hostname"`
+	BackupNodeHostname     types.String `tfsdk:"backup_node_hostname"`
+	MonitoringNodeHostname types.String `tfsdk:"monitoring_node_hostname"`
+}
+
+func InfrastructureDetailsAttributeSchema() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "The hostnames of the nodes the service runs on.",
+		Computed:            true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.UseStateForUnknown(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"primary_node_hostname": schema.StringAttribute{
+				MarkdownDescription: "The hostname of the primary node.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"backup_node_hostname": schema.StringAttribute{
+				MarkdownDescription: "The hostname of the backup node. Only set for high-availability services.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"monitoring_node_hostname": schema.StringAttribute{
+				MarkdownDescription: "The hostname of the monitoring node. Only set for high-availability services.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func InfrastructureDetailsObjectType() types.ObjectType {
+	return basetypes.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"primary_node_hostname":    types.StringType,
+			"backup_node_hostname":     types.StringType,
+			"monitoring_node_hostname": types.StringType,
+		},
+	}
+}
+
+func (m InfrastructureDetailsModel) ToObjectValue() (basetypes.ObjectValue, diag.Diagnostics) {
+	return types.ObjectValue(
+		InfrastructureDetailsObjectType().AttrTypes,
+		map[string]attr.Value{
+			"primary_node_hostname":    m.PrimaryNodeHostname,
+			"backup_node_hostname":     m.BackupNodeHostname,
+			"monitoring_node_hostname": m.MonitoringNodeHostname,
+		})
+}
@@ -58,3 +58,43 @@ func CalculateMsgVpnSpoolUsageFromMaxSpoolUsage(maxSpoolUsage int64) int64 {
 func NewMaxSpoolUsagePlanModifier() planmodifier.Int64 {
 	return MaxSpoolUsagePlanModifier{}
 }
+
+// MaxSpoolUsageDependentPlanModifier is a plan modifier that marks a value as unknown when the max_spool_usage
+// parameter in the service resource changes, as the value is only known once the message spool has been resized.
+type MaxSpoolUsageDependentPlanModifier struct{}
+
+func (m MaxSpoolUsageDependentPlanModifier) Description(ctx context.Context) string {
+	return "Marks the value as unknown when max_spool_usage changes"
+}
+
+func (m MaxSpoolUsageDependentPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return "Marks the value as unknown when max_spool_usage changes"
+}
+
+func (m MaxSpoolUsageDependentPlanModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	// Do nothing if there is no state value, the value is unknown on creation anyway.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var maxSpoolUsage types.Int64
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("max_spool_usage"), &maxSpoolUsage)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plannedMaxSpoolUsage types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("max_spool_usage"), &plannedMaxSpoolUsage)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !maxSpoolUsage.Equal(plannedMaxSpoolUsage) && !plannedMaxSpoolUsage.IsNull() && !plannedMaxSpoolUsage.IsUnknown() {
+		resp.PlanValue = types.Int64Unknown()
+	}
+}
+
+// NewMaxSpoolUsageDependentPlanModifier creates a new MaxSpoolUsageDependentPlanModifier
+func NewMaxSpoolUsageDependentPlanModifier() planmodifier.Int64 {
+	return MaxSpoolUsageDependentPlanModifier{}
+}
@@ -5,7 +5,11 @@ import (
 	"terraform-provider-solacecloud/internal/model"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestMaxSpoolUsagePlanModifier_Description(t *testing.T) {
@@ -121,3 +125,58 @@ func TestCalculateMsgVpnSpoolUsageFromMaxSpoolUsage(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxSpoolUsageDependentPlanModifier_PlanModifyInt64(t *testing.T) {
+	tests := []struct {
+		name          string
+		stateSpool    int64
+		plannedSpool  int64
+		expectUnknown bool
+	}{
+		{
+			name:          "max spool usage unchanged - keeps the planned value",
+			stateSpool:    200,
+			plannedSpool:  200,
+			expectUnknown: false,
+		},
+		{
+			name:          "max spool usage changed - value is unknown",
+			stateSpool:    200,
+			plannedSpool:  400,
+			expectUnknown: true,
+		},
+	}
+
+	ctx := context.Background()
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"max_spool_usage": schema.Int64Attribute{Optional: true},
+		},
+	}
+	objectValue := func(spool int64) tftypes.Value {
+		return tftypes.NewValue(testSchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"max_spool_usage": tftypes.NewValue(tftypes.Number, spool),
+		})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := planmodifier.Int64Request{
+				State:      tfsdk.State{Schema: testSchema, Raw: objectValue(tt.stateSpool)},
+				Plan:       tfsdk.Plan{Schema: testSchema, Raw: objectValue(tt.plannedSpool)},
+				StateValue: types.Int64Value(20),
+				PlanValue:  types.Int64Value(20),
+			}
+			resp := &planmodifier.Int64Response{PlanValue: req.PlanValue}
+
+			model.MaxSpoolUsageDependentPlanModifier{}.PlanModifyInt64(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no errors, but got: %v", resp.Diagnostics)
+			}
+			if resp.PlanValue.IsUnknown() != tt.expectUnknown {
+				t.Errorf("Expected unknown: %v, got plan value %s", tt.expectUnknown, resp.PlanValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Package model contains the MessageSpoolDetails schema which is an object nested in the service resource.  It
+// describes how the service's message spool is sized and billed: the size included with the service class, the total
+// size and how many GB of expansion are billed on top of the service class.
+
+type MessageSpoolDetailsModel struct {
+	DefaultGbSize    types.Int64 `tfsdk:"default_gb_size"`
+	ExpandedGbBilled types.Int64 `tfsdk:"expanded_gb_billed"`
+	TotalGbSize      types.Int64 `tfsdk:"total_gb_size"`
+}
+
+func MessageSpoolDetailsAttributeSchema() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "The message spool size and billing details.",
+		Computed:            true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.UseStateForUnknown(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"default_gb_size": schema.Int64Attribute{
+				MarkdownDescription: "The default message spool size for the service class, in gigabytes (GB).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"expanded_gb_billed": schema.Int64Attribute{
+				MarkdownDescription: "The number of gigabytes (GB) of message spool expansion billed on top of the service class.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					NewMaxSpoolUsageDependentPlanModifier(),
+				},
+			},
+			"total_gb_size": schema.Int64Attribute{
+				MarkdownDescription: "The total message spool size, in gigabytes (GB).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					NewMaxSpoolUsageDependentPlanModifier(),
+				},
+			},
+		},
+	}
+}
+
+func MessageSpoolDetailsObjectType() types.ObjectType {
+	return basetypes.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"default_gb_size":    types.Int64Type,
+			"expanded_gb_billed": types.Int64Type,
+			"total_gb_size":      types.Int64Type,
+		},
+	}
+}
+
+func (m MessageSpoolDetailsModel) ToObjectValue() (basetypes.ObjectValue, diag.Diagnostics) {
+	return types.ObjectValue(
+		MessageSpoolDetailsObjectType().AttrTypes,
+		map[string]attr.Value{
+			"default_gb_size":    m.DefaultGbSize,
+			"expanded_gb_billed": m.ExpandedGbBilled,
+			"total_gb_size":      m.TotalGbSize,
+		})
+}
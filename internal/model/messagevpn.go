@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -22,7 +23,9 @@ import (
 // Their attribute field name refer to which role they map to.
 // It also describes how messaging clients authentication has been configured and which authentication methods are
 // enabled.
-// Finally, this schema also describes the service's limits for the Message VPN.
+// Finally, this schema also describes the service's limits for the Message VPN, and its remaining settings such as
+// OAuth authentication and SEMP over the message bus.  Mission Control does not offer a way to change these settings,
+// they are read-only here and can be managed with the solacebroker provider.
 
 type MessageVpnModel struct {
 	Name                                        types.String          `tfsdk:"name"`
@@ -30,6 +33,11 @@ type MessageVpnModel struct {
 	AuthenticationBasicType                     types.String          `tfsdk:"authentication_basic_type"`
 	AuthenticationClientCertEnabled             types.Bool            `tfsdk:"authentication_client_cert_enabled"`
 	AuthenticationClientCertValidateDateEnabled types.Bool            `tfsdk:"authentication_client_cert_validate_date_enabled"`
+	AuthenticationOauthEnabled                  types.Bool            `tfsdk:"authentication_oauth_enabled"`
+	Enabled                                     types.Bool            `tfsdk:"enabled"`
+	EventLargeMsgThreshold                      types.Int64           `tfsdk:"event_large_msg_threshold"`
+	SubDomainName                               types.String          `tfsdk:"sub_domain_name"`
+	SempOverMessageBus                          basetypes.ObjectValue `tfsdk:"semp_over_message_bus"`
 	MaxConnectionCount                          types.Int64           `tfsdk:"max_connection_count"`
 	MaxEgressFlowCount                          types.Int64           `tfsdk:"max_egress_flow_count"`
 	MaxEndpointCount                            types.Int64           `tfsdk:"max_endpoint_count"`
@@ -49,133 +57,187 @@ func MessageVpnAttributeSchema() schema.Attribute {
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "The Message VPN details",
 		Computed:            true,
-		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the Message VPN.",
-				Computed:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
-			},
-			"authentication_basic_enabled": schema.BoolAttribute{
-				MarkdownDescription: "Indicates whether basic authentication is enabled.",
-				Computed:            true,
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.UseStateForUnknown(),
-				},
-			},
-			"authentication_basic_type": schema.StringAttribute{
-				MarkdownDescription: "The authentication type.",
-				Computed:            true,
-				Validators: []validator.String{
-					stringvalidator.OneOf(
-						"INTERNAL",
-						"LDAP",
-						"RADIUS",
-						"NONE",
-					),
-				},
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
-			},
-			"authentication_client_cert_enabled": schema.BoolAttribute{
-				MarkdownDescription: "Indicates whether client certificate authentication is enabled.",
-				Computed:            true,
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.UseStateForUnknown(),
-				},
-			},
-			"authentication_client_cert_validate_date_enabled": schema.BoolAttribute{
-				MarkdownDescription: "Indicates whether the validation of the 'Not Before' and 'Not After' dates in a client certificate is enabled.",
-				Computed:            true,
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.UseStateForUnknown(),
-				},
-			},
-			"max_connection_count": schema.Int64Attribute{
-				MarkdownDescription: "The maximum number of clients that are permitted to simultaneously connect to the Message VPN.\n",
-				Computed:            true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
-				},
-			},
-			"max_egress_flow_count": schema.Int64Attribute{
-				MarkdownDescription: "The total permitted number of ingress flows (that is, Guaranteed Message client publish flows) for a Message VPN.",
-				Computed:            true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
-				},
-			},
-			"max_endpoint_count": schema.Int64Attribute{
-				MarkdownDescription: "The maximum number of flows that can bind to a non-exclusive durable topic endpoint.",
-				Computed:            true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
-				},
-			},
-			"max_ingress_flow_count": schema.Int64Attribute{
-				MarkdownDescription: "The total permitted number of ingress flows (that is, Guaranteed Message client publish flows) for a Message VPN.",
-				Computed:            true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
-				},
-			},
-			"max_msg_spool_usage": schema.Int64Attribute{
-				MarkdownDescription: "The maximum message spool usage",
-				Computed:            true,
-				PlanModifiers: []planmodifier.Int64{
-					NewMaxSpoolUsagePlanModifier(),
-				},
-			},
-			"max_subscription_count": schema.Int64Attribute{
-				MarkdownDescription: "The maximum number of unique subscriptions.",
-				Computed:            true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
-				},
-			},
-			"max_transacted_session_count": schema.Int64Attribute{
-				MarkdownDescription: "The maximum number of simultaneous transacted sessions and/or XA Sessions allowed for the given Message VPN.",
-				Computed:            true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
-				},
-			},
-			"max_transaction_count": schema.Int64Attribute{
-				MarkdownDescription: "The total number of simultaneous transactions (both local transactions and transactions " +
-					"within distributed/XA transaction branches) in a Message VPN.",
-				Computed: true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
-				},
-			},
-			"truststore_uri": schema.StringAttribute{
-				MarkdownDescription: "The URI for the TLS trust store.",
-				Computed:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
-			},
-			"manager_management_credential": BasicAuthCredentialAttributeSchema(),
-			"editor_management_credential":  BasicAuthCredentialAttributeSchema(),
-			"viewer_management_credential":  BasicAuthCredentialAttributeSchema(),
-			"messaging_client_credential":   BasicAuthCredentialAttributeSchema(),
-		},
+		Attributes:          messageVpnAttributes(NewMaxSpoolUsagePlanModifier()),
 		PlanModifiers: []planmodifier.Object{
 			objectplanmodifier.UseStateForUnknown(),
 		},
 	}
 }
 
-func (m MessageVpnModel) ToObjectValue() (basetypes.ObjectValue, diag.Diagnostics) {
-	return types.ObjectValue(
-		map[string]attr.Type{
+// MessageVpnListAttributeSchema describes every Message VPN hosted by the service.  Unlike the service's own Message
+// VPN, the spool usage of the other Message VPNs is not derived from max_spool_usage, so it is only known once the
+// message spool has been resized.
+func MessageVpnListAttributeSchema() schema.Attribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "The details of all the Message VPNs hosted by the service.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: messageVpnAttributes(NewMaxSpoolUsageDependentPlanModifier()),
+		},
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func messageVpnAttributes(maxMsgSpoolUsagePlanModifier planmodifier.Int64) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The name of the Message VPN.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"authentication_basic_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Indicates whether basic authentication is enabled.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"authentication_basic_type": schema.StringAttribute{
+			MarkdownDescription: "The authentication type.",
+			Computed:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(
+					"INTERNAL",
+					"LDAP",
+					"RADIUS",
+					"NONE",
+				),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"authentication_client_cert_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Indicates whether client certificate authentication is enabled.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"authentication_client_cert_validate_date_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Indicates whether the validation of the 'Not Before' and 'Not After' dates in a client certificate is enabled.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"authentication_oauth_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Indicates whether OAuth authentication is enabled.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"enabled": schema.BoolAttribute{
+			MarkdownDescription: "Indicates whether the Message VPN is enabled.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"event_large_msg_threshold": schema.Int64Attribute{
+			MarkdownDescription: "The size of a message, in kilobytes (KB), above which a large message event is generated.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"sub_domain_name": schema.StringAttribute{
+			MarkdownDescription: "The generated hostname assigned to the Message VPN. For example, 'mr54hcalmefac.messaging.solace.cloud'.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"semp_over_message_bus": SempOverMessageBusAttributeSchema(),
+		"max_connection_count": schema.Int64Attribute{
+			MarkdownDescription: "The maximum number of clients that are permitted to simultaneously connect to the Message VPN.\n",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_egress_flow_count": schema.Int64Attribute{
+			MarkdownDescription: "The total permitted number of ingress flows (that is, Guaranteed Message client publish flows) for a Message VPN.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_endpoint_count": schema.Int64Attribute{
+			MarkdownDescription: "The maximum number of flows that can bind to a non-exclusive durable topic endpoint.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_ingress_flow_count": schema.Int64Attribute{
+			MarkdownDescription: "The total permitted number of ingress flows (that is, Guaranteed Message client publish flows) for a Message VPN.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_msg_spool_usage": schema.Int64Attribute{
+			MarkdownDescription: "The maximum message spool usage",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				maxMsgSpoolUsagePlanModifier,
+			},
+		},
+		"max_subscription_count": schema.Int64Attribute{
+			MarkdownDescription: "The maximum number of unique subscriptions.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_transacted_session_count": schema.Int64Attribute{
+			MarkdownDescription: "The maximum number of simultaneous transacted sessions and/or XA Sessions allowed for the given Message VPN.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"max_transaction_count": schema.Int64Attribute{
+			MarkdownDescription: "The total number of simultaneous transactions (both local transactions and transactions " +
+				"within distributed/XA transaction branches) in a Message VPN.",
+			Computed: true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"truststore_uri": schema.StringAttribute{
+			MarkdownDescription: "The URI for the TLS trust store.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"manager_management_credential": BasicAuthCredentialAttributeSchema(),
+		"editor_management_credential":  BasicAuthCredentialAttributeSchema(),
+		"viewer_management_credential":  BasicAuthCredentialAttributeSchema(),
+		"messaging_client_credential":   BasicAuthCredentialAttributeSchema(),
+	}
+}
+
+func MessageVpnObjectType() types.ObjectType {
+	return basetypes.ObjectType{
+		AttrTypes: map[string]attr.Type{
 			"name":                                             types.StringType,
 			"authentication_basic_enabled":                     types.BoolType,
 			"authentication_basic_type":                        types.StringType,
 			"authentication_client_cert_enabled":               types.BoolType,
 			"authentication_client_cert_validate_date_enabled": types.BoolType,
+			"authentication_oauth_enabled":                     types.BoolType,
+			"enabled":                                          types.BoolType,
+			"event_large_msg_threshold":                        types.Int64Type,
+			"sub_domain_name":                                  types.StringType,
+			"semp_over_message_bus":                            SempOverMessageBusObjectType(),
 			"max_connection_count":                             types.Int64Type,
 			"max_egress_flow_count":                            types.Int64Type,
 			"max_endpoint_count":                               types.Int64Type,
@@ -190,12 +252,23 @@ func (m MessageVpnModel) ToObjectValue() (basetypes.ObjectValue, diag.Diagnostic
 			"viewer_management_credential":                     BasicAuthCredentialObjectType(),
 			"messaging_client_credential":                      BasicAuthCredentialObjectType(),
 		},
+	}
+}
+
+func (m MessageVpnModel) ToObjectValue() (basetypes.ObjectValue, diag.Diagnostics) {
+	return types.ObjectValue(
+		MessageVpnObjectType().AttrTypes,
 		map[string]attr.Value{
 			"name":                                             m.Name,
 			"authentication_basic_enabled":                     m.AuthenticationBasicEnabled,
 			"authentication_basic_type":                        m.AuthenticationBasicType,
 			"authentication_client_cert_enabled":               m.AuthenticationClientCertEnabled,
 			"authentication_client_cert_validate_date_enabled": m.AuthenticationClientCertValidateDateEnabled,
+			"authentication_oauth_enabled":                     m.AuthenticationOauthEnabled,
+			"enabled":                                          m.Enabled,
+			"event_large_msg_threshold":                        m.EventLargeMsgThreshold,
+			"sub_domain_name":                                  m.SubDomainName,
+			"semp_over_message_bus":                            m.SempOverMessageBus,
 			"max_connection_count":                             m.MaxConnectionCount,
 			"max_egress_flow_count":                            m.MaxEgressFlowCount,
 			"max_endpoint_count":                               m.MaxEndpointCount,
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Package model contains the SempOverMessageBus schema which is an object nested in the MessageVpn object.  It
+// describes whether messaging clients can send SEMP requests over the message bus, and which groups of SEMP
+// commands they are allowed to use.
+
+type SempOverMessageBusModel struct {
+	Enabled                    types.Bool `tfsdk:"enabled"`
+	ShowCommandsEnabled        types.Bool `tfsdk:"show_commands_enabled"`
+	AdminCommandsEnabled       types.Bool `tfsdk:"admin_commands_enabled"`
+	ClientAdminCommandsEnabled types.Bool `tfsdk:"client_admin_commands_enabled"`
+	CacheCommandsEnabled       types.Bool `tfsdk:"cache_commands_enabled"`
+}
+
+func SempOverMessageBusAttributeSchema() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "The SEMP over the message bus settings of the Message VPN.",
+		Computed:            true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.UseStateForUnknown(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Indicates whether SEMP over the message bus is enabled. When enabled, clients have " +
+					"access to a limited subset of the event broker management commands.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"show_commands_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Indicates whether clients can use SEMP 'show' commands.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"admin_commands_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Indicates whether clients can use SEMP admin commands.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_admin_commands_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Indicates whether clients can use SEMP client-admin commands.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cache_commands_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Indicates whether clients can use SEMP cache commands.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func SempOverMessageBusObjectType() types.ObjectType {
+	return basetypes.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"enabled":                       types.BoolType,
+			"show_commands_enabled":         types.BoolType,
+			"admin_commands_enabled":        types.BoolType,
+			"client_admin_commands_enabled": types.BoolType,
+			"cache_commands_enabled":        types.BoolType,
+		},
+	}
+}
+
+func (m SempOverMessageBusModel) ToObjectValue() (basetypes.ObjectValue, diag.Diagnostics) {
+	return types.ObjectValue(
+		SempOverMessageBusObjectType().AttrTypes,
+		map[string]attr.Value{
+			"enabled":                       m.Enabled,
+			"show_commands_enabled":         m.ShowCommandsEnabled,
+			"admin_commands_enabled":        m.AdminCommandsEnabled,
+			"client_admin_commands_enabled": m.ClientAdminCommandsEnabled,
+			"cache_commands_enabled":        m.CacheCommandsEnabled,
+		})
+}
@@ -1,98 +1,119 @@
 package provider
 
 import (
+	"bytes"
 	"context"
-	"errors"
 	"io"
+	"net/http"
+	"terraform-provider-solacecloud/internal/shared"
 	mc "terraform-provider-solacecloud/missioncontrol"
-	"time"
 )
 
+// RetryableClientWithResponses retries the Mission Control API calls that fail while Solace Cloud is rate limiting
+// requests or temporarily unavailable, following a shared.RetryPolicy.
 type RetryableClientWithResponses struct {
-	api         CRUDClientWithResponses
-	maxRetries  int
-	waitSeconds int
-}
-
-type ApiError interface {
-	StatusCode() int
+	api    CRUDClientWithResponses
+	policy shared.RetryPolicy
 }
 
 type CRUDClientWithResponses interface {
 	CreateServiceWithResponse(ctx context.Context, body mc.CreateServiceJSONRequestBody, reqEditors ...mc.RequestEditorFn) (*mc.CreateServiceResponse, error)
 	GetServiceWithResponse(ctx context.Context, id string, params *mc.GetServiceParams, reqEditors ...mc.RequestEditorFn) (*mc.GetServiceResponse, error)
+	GetServicesWithResponse(ctx context.Context, params *mc.GetServicesParams, reqEditors ...mc.RequestEditorFn) (*mc.GetServicesResponse, error)
 	DeleteServiceWithResponse(ctx context.Context, id string, reqEditors ...mc.RequestEditorFn) (*mc.DeleteServiceResponse, error)
 	UpdateServiceWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...mc.RequestEditorFn) (*mc.UpdateServiceResponse, error)
 	UpdateMessageSpoolWithBodyWithResponse(ctx context.Context, serviceId string, contentType string, body io.Reader, reqEditors ...mc.RequestEditorFn) (*mc.UpdateMessageSpoolResponse, error)
 	GetServiceOperationWithResponse(ctx context.Context, serviceId string, operationId string, reqEditors ...mc.RequestEditorFn) (*mc.GetServiceOperationResponse, error)
+	GetDatacenterWithResponse(ctx context.Context, id string, reqEditors ...mc.RequestEditorFn) (*mc.GetDatacenterResponse, error)
+	GetDatacentersWithResponse(ctx context.Context, params *mc.GetDatacentersParams, reqEditors ...mc.RequestEditorFn) (*mc.GetDatacentersResponse, error)
+	GetEventBrokerServiceVersionsWithResponse(ctx context.Context, id string, reqEditors ...mc.RequestEditorFn) (*mc.GetEventBrokerServiceVersionsResponse, error)
+	GetServiceClassesWithResponse(ctx context.Context, params *mc.GetServiceClassesParams, reqEditors ...mc.RequestEditorFn) (*mc.GetServiceClassesResponse, error)
+	GetLimitsWithResponse(ctx context.Context, orgId string, reqEditors ...mc.RequestEditorFn) (*mc.GetLimitsResponse, error)
 }
 
-func NewRetryableClient(api CRUDClientWithResponses, maxRetries, waitSeconds int) *RetryableClientWithResponses {
-	return &RetryableClientWithResponses{api, maxRetries, waitSeconds}
-}
-
-func isRetryableError(err error) bool {
-	var apiErr ApiError
-	if errors.As(err, &apiErr) {
-		return apiErr.StatusCode() >= 500 && apiErr.StatusCode() < 600
-	}
-	return false
-}
-
-func retry[T any](ctx context.Context, fn func() (T, error), maxRetries, waitSec int) (T, error) {
-	var lastErr error
-	var zero T
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		var result T
-		result, err := fn()
-		if err == nil || !isRetryableError(err) {
-			return result, err
-		}
-		lastErr = err
-		if attempt < maxRetries-1 {
-			select {
-			case <-ctx.Done():
-				return zero, ctx.Err()
-			case <-time.After(time.Duration(waitSec) * time.Second):
-				// retry
-			}
-		}
-	}
-	return zero, lastErr
+func NewRetryableClient(api CRUDClientWithResponses, policy shared.RetryPolicy) *RetryableClientWithResponses {
+	return &RetryableClientWithResponses{api, policy}
 }
 
+// CreateServiceWithResponse is only retried when the request never reached Mission Control, as retrying a request that
+// failed after being received could create the service twice.
 func (w *RetryableClientWithResponses) CreateServiceWithResponse(ctx context.Context, body mc.CreateServiceJSONRequestBody, reqEditors ...mc.RequestEditorFn) (*mc.CreateServiceResponse, error) {
-	return retry(ctx, func() (*mc.CreateServiceResponse, error) {
-		return w.api.CreateServiceWithResponse(ctx, body)
-	}, w.maxRetries, w.waitSeconds)
+	return shared.Retry(ctx, w.policy, false, func() (*mc.CreateServiceResponse, error) {
+		return w.api.CreateServiceWithResponse(ctx, body, reqEditors...)
+	}, func(resp *mc.CreateServiceResponse) *http.Response { return resp.HTTPResponse })
 }
 
 func (w *RetryableClientWithResponses) GetServiceWithResponse(ctx context.Context, id string, params *mc.GetServiceParams, reqEditors ...mc.RequestEditorFn) (*mc.GetServiceResponse, error) {
-	return retry(ctx, func() (*mc.GetServiceResponse, error) {
+	return shared.Retry(ctx, w.policy, true, func() (*mc.GetServiceResponse, error) {
 		return w.api.GetServiceWithResponse(ctx, id, params, reqEditors...)
-	}, w.maxRetries, w.waitSeconds)
+	}, func(resp *mc.GetServiceResponse) *http.Response { return resp.HTTPResponse })
+}
+
+func (w *RetryableClientWithResponses) GetServicesWithResponse(ctx context.Context, params *mc.GetServicesParams, reqEditors ...mc.RequestEditorFn) (*mc.GetServicesResponse, error) {
+	return shared.Retry(ctx, w.policy, true, func() (*mc.GetServicesResponse, error) {
+		return w.api.GetServicesWithResponse(ctx, params, reqEditors...)
+	}, func(resp *mc.GetServicesResponse) *http.Response { return resp.HTTPResponse })
 }
 
 func (w *RetryableClientWithResponses) DeleteServiceWithResponse(ctx context.Context, id string, reqEditors ...mc.RequestEditorFn) (*mc.DeleteServiceResponse, error) {
-	return retry(ctx, func() (*mc.DeleteServiceResponse, error) {
+	return shared.Retry(ctx, w.policy, true, func() (*mc.DeleteServiceResponse, error) {
 		return w.api.DeleteServiceWithResponse(ctx, id, reqEditors...)
-	}, w.maxRetries, w.waitSeconds)
+	}, func(resp *mc.DeleteServiceResponse) *http.Response { return resp.HTTPResponse })
 }
 
+// UpdateServiceWithBodyWithResponse reads the body once, so that every attempt sends all of it.
 func (w *RetryableClientWithResponses) UpdateServiceWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...mc.RequestEditorFn) (*mc.UpdateServiceResponse, error) {
-	return retry(ctx, func() (*mc.UpdateServiceResponse, error) {
-		return w.api.UpdateServiceWithBodyWithResponse(ctx, id, contentType, body, reqEditors...)
-	}, w.maxRetries, w.waitSeconds)
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return shared.Retry(ctx, w.policy, true, func() (*mc.UpdateServiceResponse, error) {
+		return w.api.UpdateServiceWithBodyWithResponse(ctx, id, contentType, bytes.NewReader(payload), reqEditors...)
+	}, func(resp *mc.UpdateServiceResponse) *http.Response { return resp.HTTPResponse })
 }
 
 func (w *RetryableClientWithResponses) UpdateMessageSpoolWithBodyWithResponse(ctx context.Context, serviceId string, contentType string, body io.Reader, reqEditors ...mc.RequestEditorFn) (*mc.UpdateMessageSpoolResponse, error) {
-	return retry(ctx, func() (*mc.UpdateMessageSpoolResponse, error) {
-		return w.api.UpdateMessageSpoolWithBodyWithResponse(ctx, serviceId, contentType, body, reqEditors...)
-	}, w.maxRetries, w.waitSeconds)
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return shared.Retry(ctx, w.policy, true, func() (*mc.UpdateMessageSpoolResponse, error) {
+		return w.api.UpdateMessageSpoolWithBodyWithResponse(ctx, serviceId, contentType, bytes.NewReader(payload), reqEditors...)
+	}, func(resp *mc.UpdateMessageSpoolResponse) *http.Response { return resp.HTTPResponse })
 }
 
 func (w *RetryableClientWithResponses) GetServiceOperationWithResponse(ctx context.Context, serviceId string, operationId string, reqEditors ...mc.RequestEditorFn) (*mc.GetServiceOperationResponse, error) {
-	return retry(ctx, func() (*mc.GetServiceOperationResponse, error) {
+	return shared.Retry(ctx, w.policy, true, func() (*mc.GetServiceOperationResponse, error) {
 		return w.api.GetServiceOperationWithResponse(ctx, serviceId, operationId, reqEditors...)
-	}, w.maxRetries, w.waitSeconds)
+	}, func(resp *mc.GetServiceOperationResponse) *http.Response { return resp.HTTPResponse })
+}
+
+func (w *RetryableClientWithResponses) GetDatacenterWithResponse(ctx context.Context, id string, reqEditors ...mc.RequestEditorFn) (*mc.GetDatacenterResponse, error) {
+	return shared.Retry(ctx, w.policy, true, func() (*mc.GetDatacenterResponse, error) {
+		return w.api.GetDatacenterWithResponse(ctx, id, reqEditors...)
+	}, func(resp *mc.GetDatacenterResponse) *http.Response { return resp.HTTPResponse })
+}
+
+func (w *RetryableClientWithResponses) GetDatacentersWithResponse(ctx context.Context, params *mc.GetDatacentersParams, reqEditors ...mc.RequestEditorFn) (*mc.GetDatacentersResponse, error) {
+	return shared.Retry(ctx, w.policy, true, func() (*mc.GetDatacentersResponse, error) {
+		return w.api.GetDatacentersWithResponse(ctx, params, reqEditors...)
+	}, func(resp *mc.GetDatacentersResponse) *http.Response { return resp.HTTPResponse })
+}
+
+func (w *RetryableClientWithResponses) GetEventBrokerServiceVersionsWithResponse(ctx context.Context, id string, reqEditors ...mc.RequestEditorFn) (*mc.GetEventBrokerServiceVersionsResponse, error) {
+	return shared.Retry(ctx, w.policy, true, func() (*mc.GetEventBrokerServiceVersionsResponse, error) {
+		return w.api.GetEventBrokerServiceVersionsWithResponse(ctx, id, reqEditors...)
+	}, func(resp *mc.GetEventBrokerServiceVersionsResponse) *http.Response { return resp.HTTPResponse })
+}
+
+func (w *RetryableClientWithResponses) GetServiceClassesWithResponse(ctx context.Context, params *mc.GetServiceClassesParams, reqEditors ...mc.RequestEditorFn) (*mc.GetServiceClassesResponse, error) {
+	return shared.Retry(ctx, w.policy, true, func() (*mc.GetServiceClassesResponse, error) {
+		return w.api.GetServiceClassesWithResponse(ctx, params, reqEditors...)
+	}, func(resp *mc.GetServiceClassesResponse) *http.Response { return resp.HTTPResponse })
+}
+
+func (w *RetryableClientWithResponses) GetLimitsWithResponse(ctx context.Context, orgId string, reqEditors ...mc.RequestEditorFn) (*mc.GetLimitsResponse, error) {
+	return shared.Retry(ctx, w.policy, true, func() (*mc.GetLimitsResponse, error) {
+		return w.api.GetLimitsWithResponse(ctx, orgId, reqEditors...)
+	}, func(resp *mc.GetLimitsResponse) *http.Response { return resp.HTTPResponse })
 }
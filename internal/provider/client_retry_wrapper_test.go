@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"terraform-provider-solacecloud/internal/shared"
+	mc "terraform-provider-solacecloud/missioncontrol"
+	"testing"
+)
+
+// flakyClient answers with HTTP 503 a number of times before succeeding, and records the bodies it receives.
+type flakyClient struct {
+	CRUDClientWithResponses
+	failures int
+	calls    int
+	bodies   []string
+}
+
+func (c *flakyClient) status() *http.Response {
+	c.calls++
+	if c.calls <= c.failures {
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+}
+
+func (c *flakyClient) CreateServiceWithResponse(_ context.Context, _ mc.CreateServiceJSONRequestBody, _ ...mc.RequestEditorFn) (*mc.CreateServiceResponse, error) {
+	return &mc.CreateServiceResponse{HTTPResponse: c.status()}, nil
+}
+
+func (c *flakyClient) UpdateServiceWithBodyWithResponse(_ context.Context, _ string, _ string, body io.Reader, _ ...mc.RequestEditorFn) (*mc.UpdateServiceResponse, error) {
+	payload, _ := io.ReadAll(body)
+	c.bodies = append(c.bodies, string(payload))
+	return &mc.UpdateServiceResponse{HTTPResponse: c.status()}, nil
+}
+
+func TestRetryableClient(t *testing.T) {
+	ctx := context.Background()
+	policy := shared.RetryPolicy{MaxRetries: 3}
+
+	t.Run("update is retried with the whole body", func(t *testing.T) {
+		client := &flakyClient{failures: 2}
+		resp, err := NewRetryableClient(client, policy).UpdateServiceWithBodyWithResponse(ctx, "id", "application/json", bytes.NewReader([]byte(`{"name":"new"}`)))
+		if err != nil || resp.StatusCode() != http.StatusOK {
+			t.Fatalf("expected HTTP 200, got %v %v", resp, err)
+		}
+		if len(client.bodies) != 3 {
+			t.Fatalf("expected 3 attempts, got %d", len(client.bodies))
+		}
+		for _, body := range client.bodies {
+			if body != `{"name":"new"}` {
+				t.Errorf("expected every attempt to send the body, got %q", body)
+			}
+		}
+	})
+
+	t.Run("create is not retried once received", func(t *testing.T) {
+		client := &flakyClient{failures: 1}
+		resp, err := NewRetryableClient(client, policy).CreateServiceWithResponse(ctx, mc.CreateServiceJSONRequestBody{})
+		if err != nil || resp.StatusCode() != http.StatusServiceUnavailable || client.calls != 1 {
+			t.Errorf("expected a single attempt answered with HTTP 503, got %d attempts, %v %v", client.calls, resp.StatusCode(), err)
+		}
+	})
+}
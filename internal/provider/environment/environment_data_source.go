@@ -32,7 +32,8 @@ func NewEnvironmentDataSource() datasource.DataSource {
 // EnvironmentDataSource is the data source implementation.
 type EnvironmentDataSource struct {
 	APIClient      *missioncontrol.ClientWithResponses
-	PlatformClient *platform.ClientWithResponses
+	Permissions    shared.Permissions
+	PlatformClient shared.PlatformClient
 }
 
 // EnvironmentDataSourceModel maps the data source schema data.
@@ -61,7 +62,8 @@ func (d *EnvironmentDataSource) Configure(_ context.Context, req datasource.Conf
 	}
 
 	d.APIClient = providerConfig.APIClient
-	d.PlatformClient = providerConfig.PlatformClient
+	d.Permissions = providerConfig.Permissions
+	d.PlatformClient = shared.NewRetryablePlatformClient(providerConfig.PlatformClient, providerConfig.RetryPolicy)
 }
 
 // Metadata returns the data source type name.
@@ -92,6 +94,12 @@ func (d *EnvironmentDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 
 // Read refreshes the Terraform state with the latest data.
 func (d *EnvironmentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = shared.MaskSensitiveLogFields(ctx)
+	resp.Diagnostics.Append(d.Permissions.Check("solacecloud_environment", shared.ReadEnvironmentsPermission)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	diags := d.readDataInternal(ctx, req, resp)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -142,7 +150,7 @@ func (d *EnvironmentDataSource) readDataInternal(ctx context.Context, req dataso
 	}
 
 	// Log the API response
-	tflog.Debug(ctx, fmt.Sprintf("Environments Search API Response: %s", string(searchResp.Body)))
+	tflog.Debug(ctx, fmt.Sprintf("Environments Search API Response: %s", shared.RedactBody(searchResp.Body)))
 
 	// Parse the response body
 	var environmentsResponse struct {
@@ -211,7 +219,7 @@ func (d *EnvironmentDataSource) readDataInternal(ctx context.Context, req dataso
 	}
 
 	// Log the API response
-	tflog.Debug(ctx, fmt.Sprintf("Environment API Response: %s", string(apiResp.Body)))
+	tflog.Debug(ctx, fmt.Sprintf("Environment API Response: %s", shared.RedactBody(apiResp.Body)))
 
 	// Parse response body into EnvironmentResponseEnvelope
 	var environmentResponse platform.EnvironmentResponseEnvelope
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"terraform-provider-solacecloud/internal/shared"
+	"terraform-provider-solacecloud/platform"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// EnvironmentSearchClient is the part of the platform API used to look environments up by name.
+type EnvironmentSearchClient interface {
+	SearchEnvironmentsWithResponse(ctx context.Context, params *platform.SearchEnvironmentsParams, reqEditors ...platform.RequestEditorFn) (*platform.SearchEnvironmentsResponse, error)
+}
+
+// findEnvironmentId returns the identifier of the environment with this name, and whether there is one. Not finding
+// it is not an error, the callers decide what it means.
+func findEnvironmentId(ctx context.Context, client EnvironmentSearchClient, name string) (string, bool, diag.Diagnostics) {
+	environments, diagnostics := searchEnvironments(ctx, client, &platform.SearchEnvironmentsParams{Name: &name}, name)
+	if diagnostics.HasError() {
+		return "", false, diagnostics
+	}
+
+	for _, environment := range environments {
+		if environment.Id != nil && environment.Name == name {
+			return *environment.Id, true, diagnostics
+		}
+	}
+	return "", false, diagnostics
+}
+
+// lookupEnvironmentId returns the identifier of the environment with this name.
+func lookupEnvironmentId(ctx context.Context, client EnvironmentSearchClient, name string) (string, diag.Diagnostics) {
+	environmentId, found, diagnostics := findEnvironmentId(ctx, client, name)
+	if !diagnostics.HasError() && !found {
+		diagnostics.AddError(
+			"Environment Not Found",
+			fmt.Sprintf("Could not find environment with name '%s'", name),
+		)
+	}
+	return environmentId, diagnostics
+}
+
+// lookupEnvironmentIdByNameOrId returns the identifier of the environment with this name, or else with this
+// identifier, so that the environment can be given either way.
+func lookupEnvironmentIdByNameOrId(ctx context.Context, client EnvironmentSearchClient, nameOrId string) (string, diag.Diagnostics) {
+	environmentId, found, diagnostics := findEnvironmentId(ctx, client, nameOrId)
+	if diagnostics.HasError() || found {
+		return environmentId, diagnostics
+	}
+
+	pageSize := 100
+	for pageNumber := 1; ; pageNumber++ {
+		environments, diags := searchEnvironments(ctx, client,
+			&platform.SearchEnvironmentsParams{PageSize: &pageSize, PageNumber: &pageNumber}, nameOrId)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return "", diagnostics
+		}
+		for _, environment := range environments {
+			if environment.Id != nil && *environment.Id == nameOrId {
+				return nameOrId, diagnostics
+			}
+		}
+		if len(environments) < pageSize {
+			break
+		}
+	}
+
+	diagnostics.AddError(
+		"Environment Not Found",
+		fmt.Sprintf("Could not find environment with name or identifier '%s'", nameOrId),
+	)
+	return "", diagnostics
+}
+
+// searchEnvironments returns one page of the environments matching the search parameters. The environment looked for
+// is only used in the errors.
+func searchEnvironments(ctx context.Context, client EnvironmentSearchClient, params *platform.SearchEnvironmentsParams, environment string) ([]platform.EnvironmentResponse, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	if client == nil {
+		diagnostics.AddError(
+			"Unconfigured Platform API Client",
+			fmt.Sprintf("Cannot look up environment %q, the provider has not been configured.", environment),
+		)
+		return nil, diagnostics
+	}
+
+	searchResp, err := client.SearchEnvironmentsWithResponse(ctx, params)
+	if err != nil {
+		diagnostics.AddError(
+			"Error Searching Environments",
+			fmt.Sprintf("Could not search environments: %s", err),
+		)
+		return nil, diagnostics
+	}
+
+	errorHandler := shared.NewPlatformErrorResponseAdaptor(
+		http.StatusOK,
+		searchResp.Body,
+		searchResp.HTTPResponse,
+		searchResp.JSON400,
+		searchResp.JSON401,
+		searchResp.JSON403,
+		searchResp.JSON404,
+		nil,
+	)
+	if errorHandler.HandleError(&diagnostics) {
+		return nil, diagnostics
+	}
+
+	var environments platform.EnvironmentsResponseEnvelope
+	if err := json.Unmarshal(searchResp.Body, &environments); err != nil {
+		diagnostics.AddError(
+			"Error Parsing Environments Response",
+			fmt.Sprintf("Could not parse environments response: %s", err),
+		)
+		return nil, diagnostics
+	}
+
+	if environments.Data == nil {
+		return nil, diagnostics
+	}
+	return *environments.Data, diagnostics
+}
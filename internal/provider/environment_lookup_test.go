@@ -0,0 +1,19 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLookupEnvironmentIdByNameOrIdReportsSearchErrors(t *testing.T) {
+	client := &stubEnvironmentClient{body: `not json`}
+
+	environmentId, diags := lookupEnvironmentIdByNameOrId(context.Background(), client, "Production")
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Error Parsing Environments Response" {
+		t.Fatalf("Expected the search error, got %q: %v", environmentId, diags)
+	}
+	// Only an environment that is not found by name is searched by identifier.
+	if client.searches != 1 {
+		t.Errorf("Expected 1 search, got %d", client.searches)
+	}
+}
@@ -2,28 +2,39 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"terraform-provider-solacecloud/internal/provider/environment"
 	"terraform-provider-solacecloud/internal/shared"
+	"terraform-provider-solacecloud/internal/util"
 	"terraform-provider-solacecloud/missioncontrol"
 	"terraform-provider-solacecloud/platform"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/oapi-codegen/oapi-codegen/v2/pkg/securityprovider"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &solaceCloudProvider{}
+	_ provider.Provider                       = &solaceCloudProvider{}
+	_ provider.ProviderWithEphemeralResources = &solaceCloudProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -45,11 +56,47 @@ type solaceCloudProvider struct {
 
 // solaceCloudProviderModel maps provider schema data to a Go type.
 type solaceCloudProviderModel struct {
-	BaseURL            types.String `tfsdk:"base_url"`
-	APIToken           types.String `tfsdk:"api_token"`
-	APIPollingInterval types.Int64  `tfsdk:"api_polling_interval"`
+	BaseURL                  types.String  `tfsdk:"base_url"`
+	APIToken                 types.String  `tfsdk:"api_token"`
+	APIPollingInterval       types.Int64   `tfsdk:"api_polling_interval"`
+	ReplaceOnImmutableChange types.Bool    `tfsdk:"replace_on_immutable_change"`
+	MaxRetries               types.Int64   `tfsdk:"max_retries"`
+	MinBackoff               types.Int64   `tfsdk:"min_backoff"`
+	MaxBackoff               types.Int64   `tfsdk:"max_backoff"`
+	RequestTimeout           types.Int64   `tfsdk:"request_timeout"`
+	RequestsPerSecond        types.Float64 `tfsdk:"requests_per_second"`
+	Burst                    types.Int64   `tfsdk:"burst"`
+	ProxyURL                 types.String  `tfsdk:"proxy_url"`
+	CACertPEM                types.String  `tfsdk:"ca_cert_pem"`
+	CACertFile               types.String  `tfsdk:"ca_cert_file"`
+	InsecureSkipVerify       types.Bool    `tfsdk:"insecure_skip_verify"`
+	APITokenFile             types.String  `tfsdk:"api_token_file"`
+	APITokenCommand          types.String  `tfsdk:"api_token_command"`
+	OAuth2ClientCredentials  types.Object  `tfsdk:"oauth2_client_credentials"`
+	Profile                  types.String  `tfsdk:"profile"`
+	HomeCloud                types.String  `tfsdk:"home_cloud"`
+	DiscoverHomeCloud        types.Bool    `tfsdk:"discover_home_cloud"`
+	Preflight                types.Bool    `tfsdk:"preflight"`
+	DefaultEnvironment       types.String  `tfsdk:"default_environment"`
+	DefaultDatacenterId      types.String  `tfsdk:"default_datacenter_id"`
+	DefaultServiceClassId    types.String  `tfsdk:"default_service_class_id"`
 }
 
+// oauth2ClientCredentialsModel maps the oauth2_client_credentials attribute.
+type oauth2ClientCredentialsModel struct {
+	TokenURL     types.String `tfsdk:"token_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
+}
+
+// The defaults of the HTTP settings: the per-request timeout in seconds, and the rate limit of all requests together.
+const (
+	defaultRequestTimeout    = 60
+	defaultRequestsPerSecond = 10
+	defaultBurst             = 20
+)
+
 // For backward compatibility, keep the SolaceCloudProviderConfig type
 // but use the shared.ProviderConfig type internally
 type SolaceCloudProviderConfig = shared.ProviderConfig
@@ -68,13 +115,70 @@ func (p *solaceCloudProvider) Schema(_ context.Context, _ provider.SchemaRequest
 				Required:    false,
 				Optional:    true,
 				Sensitive:   false,
-				Description: "Base URL for REST API Endpoints. The PubSub+ Home Cloud your account is located determines the base URL you use.  ex: https://api.solace.cloud/",
+				Description: "Base URL for REST API Endpoints. The PubSub+ Home Cloud your account is located determines the base URL you use.  ex: https://api.solace.cloud/. Can be set as Env Variable SOLACECLOUD_BASE_URL. Default value is https://production-api.solace.cloud",
+				Validators:  []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("home_cloud"))},
+			},
+			"home_cloud": schema.StringAttribute{
+				Optional:    true,
+				Description: "Home Cloud of your account, setting the base URL for you: us, eu, au or sg, or one of their aliases usa, america, north-america, europe, australia and singapore. Conflicts with base_url. Can be set as Env Variable SOLACECLOUD_HOME_CLOUD",
+				Validators:  []validator.String{stringvalidator.OneOfCaseInsensitive(homeCloudNames()...)},
+			},
+			"discover_home_cloud": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, and the API token is rejected by the base URL, the known Home Clouds are tried and the first one accepting the token is used. Can be set as Env Variable SOLACECLOUD_DISCOVER_HOME_CLOUD. Default value is false",
+			},
+			"preflight": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, the API token is checked when the provider is configured: an invalid token fails straight away, and the permissions granted by the roles of the token are compared with the ones each resource and data source needs before anything is changed. Reading the roles needs the iam_org_roles:get and iam_users:get permissions, without them the permissions are not checked. Can be set as Env Variable SOLACECLOUD_PREFLIGHT. Default value is false",
 			},
 			"api_token": schema.StringAttribute{
 				Required:    false,
 				Optional:    true,
 				Sensitive:   true,
-				Description: "Token for authenticating with the Solace Cloud API. Can be set as Env Variable SOLACE_APITOKEN",
+				Description: "Token for authenticating with the Solace Cloud API. Can be set as Env Variable SOLACECLOUD_API_TOKEN",
+				Validators: []validator.String{stringvalidator.ConflictsWith(
+					path.MatchRoot("api_token_file"), path.MatchRoot("api_token_command"), path.MatchRoot("oauth2_client_credentials"),
+				)},
+			},
+			"api_token_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a file holding the token for authenticating with the Solace Cloud API. The file is read again when the API rejects the token. Can be set as Env Variable SOLACECLOUD_API_TOKEN_FILE",
+				Validators: []validator.String{stringvalidator.ConflictsWith(
+					path.MatchRoot("api_token_command"), path.MatchRoot("oauth2_client_credentials"),
+				)},
+			},
+			"api_token_command": schema.StringAttribute{
+				Optional:    true,
+				Description: "Command printing the token for authenticating with the Solace Cloud API on its standard output, run with sh -c (cmd /C on Windows). The token is cached, and the command run again when the API rejects it. Can be set as Env Variable SOLACECLOUD_API_TOKEN_COMMAND",
+				Validators:  []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("oauth2_client_credentials"))},
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the profile to read settings from, in ~/.solacecloud/config or the file set in Env Variable SOLACECLOUD_CONFIG_FILE. Attributes set on the provider and their Env Variables override the settings of the profile. Can be set as Env Variable SOLACECLOUD_PROFILE",
+			},
+			"oauth2_client_credentials": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Gets the token for authenticating with the Solace Cloud API from an OAuth2 authorization server, with the client credentials grant. The token is renewed before it expires",
+				Attributes: map[string]schema.Attribute{
+					"token_url": schema.StringAttribute{
+						Required:    true,
+						Description: "Token endpoint of the authorization server",
+					},
+					"client_id": schema.StringAttribute{
+						Required:    true,
+						Description: "Client ID",
+					},
+					"client_secret": schema.StringAttribute{
+						Required:    true,
+						Sensitive:   true,
+						Description: "Client secret",
+					},
+					"scopes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Scopes to request",
+					},
+				},
 			},
 			"api_polling_interval": schema.Int64Attribute{
 				Required:    false,
@@ -82,11 +186,80 @@ func (p *solaceCloudProvider) Schema(_ context.Context, _ provider.SchemaRequest
 				Sensitive:   false,
 				Description: "Polling Interval in seconds for API calls that need to wait untill a process changes status. For example wait until a SC service is marked as COMPLETED. Default value is 30 seconds",
 			},
+			"replace_on_immutable_change": schema.BoolAttribute{
+				Required:    false,
+				Optional:    true,
+				Sensitive:   false,
+				Description: "When true, services are replaced instead of failing the plan when an attribute that cannot be updated in place changes. Locked services are never replaced. Can be overridden per service. Default value is false",
+			},
+			"default_environment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name or identifier of the environment of the services that do not set environment_id. Can be set as Env Variable SOLACECLOUD_DEFAULT_ENVIRONMENT",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"default_datacenter_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Identifier of the datacenter of the services that do not set datacenter_id. Can be set as Env Variable SOLACECLOUD_DEFAULT_DATACENTER_ID",
+				Validators:  []validator.String{stringvalidator.LengthBetween(1, 50)},
+			},
+			"default_service_class_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Identifier of the service class of the services that do not set service_class_id. Can be set as Env Variable SOLACECLOUD_DEFAULT_SERVICE_CLASS_ID. Default value is DEVELOPER",
+				Validators:  []validator.String{serviceClassIdValidator()},
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of times an API call is retried when Solace Cloud answers with HTTP 429 or 5xx, or cannot be reached. Can be set as Env Variable SOLACECLOUD_MAX_RETRIES. Default value is 3",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"min_backoff": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Delay in seconds before the first retry of an API call, doubled for every following retry up to max_backoff. Can be set as Env Variable SOLACECLOUD_MIN_BACKOFF. Default value is 1 second",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"max_backoff": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum delay in seconds between two retries of an API call. Can be set as Env Variable SOLACECLOUD_MAX_BACKOFF. Default value is 30 seconds",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"request_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Timeout in seconds of a single HTTP request to the Solace Cloud API, 0 for no timeout. A request that times out is retried like any other failure. Can be set as Env Variable SOLACECLOUD_REQUEST_TIMEOUT. Default value is 60 seconds",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Average number of requests per second sent to the Solace Cloud API, shared by all the resources and data sources of a run, 0 for no limit. Can be set as Env Variable SOLACECLOUD_REQUESTS_PER_SECOND. Default value is 10",
+				Validators:  []validator.Float64{float64validator.AtLeast(0)},
+			},
+			"burst": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of requests that can be sent at once before requests_per_second applies. Can be set as Env Variable SOLACECLOUD_BURST. Default value is 20",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the proxy to send all requests through, ex: http://proxy.example.com:3128. When not set, the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables are used",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded root certificates to trust in addition to the system ones, for example the root CA of a proxy inspecting TLS traffic. Conflicts with ca_cert_file",
+				Validators:  []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file"))},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a file holding PEM encoded root certificates to trust in addition to the system ones. Conflicts with ca_cert_pem",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, the TLS certificate of the API is not verified. Only meant for local stand-ins of the Solace Cloud API, never use it against Solace Cloud. Default value is false",
+			},
 		},
 	}
 }
 
 func (p *solaceCloudProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	ctx = shared.MaskSensitiveLogFields(ctx)
 	// Retrieve provider data from configuration
 	var config solaceCloudProviderModel
 	diags := req.Config.Get(ctx, &config)
@@ -98,37 +271,23 @@ func (p *solaceCloudProvider) Configure(ctx context.Context, req provider.Config
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
 
-	if resp.Diagnostics.HasError() {
-		return
+	// The settings of a profile fill in the attributes that are not set.
+	profile := os.Getenv("SOLACECLOUD_PROFILE")
+	if config.Profile.ValueString() != "" {
+		profile = config.Profile.ValueString()
 	}
-
-	baseUrl := config.BaseURL.ValueString()
-
-	// If any of the expected configurations are missing, return
-	// errors with provider-specific guidance.
-	if baseUrl == "" {
-		baseUrl = "https://production-api.solace.cloud"
+	if profile != "" {
+		resp.Diagnostics.Append(loadProfile(&config, profile)...)
 	}
 
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
-	apiToken := os.Getenv("SOLACECLOUD_API_TOKEN")
-
-	if config.APIToken.ValueString() != "" {
-		apiToken = config.APIToken.ValueString()
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// If any of the expected configurations are missing, return
-	// errors with provider-specific guidance.
-	if apiToken == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("api_token"),
-			"Missing Solace Cloud API Token",
-			"The provider cannot create the API client as there is an unknown configuration value for the Solace API Token. "+
-				"Set the api_token value in the configuration or use the SOLACECLOUD_API_TOKEN environment variable. "+
-				"If either is already set, ensure the value is not empty.",
-		)
-	}
+	baseUrl, diags := resolveBaseURL(config)
+	resp.Diagnostics.Append(diags...)
+	discover := boolSetting(config.DiscoverHomeCloud, "SOLACECLOUD_DISCOVER_HOME_CLOUD", false, path.Root("discover_home_cloud"), &resp.Diagnostics)
+	runPreflight := boolSetting(config.Preflight, "SOLACECLOUD_PREFLIGHT", false, path.Root("preflight"), &resp.Diagnostics)
 
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
@@ -142,6 +301,30 @@ func (p *solaceCloudProvider) Configure(ctx context.Context, req provider.Config
 
 	tflog.Debug(ctx, fmt.Sprintf("api_polling_interval = %d ", apiPollingInterval))
 
+	defaultPolicy := shared.DefaultRetryPolicy()
+	retryPolicy := shared.RetryPolicy{
+		MaxRetries: int(int64Setting(config.MaxRetries, "SOLACECLOUD_MAX_RETRIES", int64(defaultPolicy.MaxRetries), path.Root("max_retries"), &resp.Diagnostics)),
+		MinBackoff: time.Duration(int64Setting(config.MinBackoff, "SOLACECLOUD_MIN_BACKOFF", int64(defaultPolicy.MinBackoff/time.Second), path.Root("min_backoff"), &resp.Diagnostics)) * time.Second,
+		MaxBackoff: time.Duration(int64Setting(config.MaxBackoff, "SOLACECLOUD_MAX_BACKOFF", int64(defaultPolicy.MaxBackoff/time.Second), path.Root("max_backoff"), &resp.Diagnostics)) * time.Second,
+	}
+	if retryPolicy.MinBackoff > retryPolicy.MaxBackoff {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_backoff"),
+			"Invalid Backoff",
+			fmt.Sprintf("max_backoff (%s) cannot be smaller than min_backoff (%s).", retryPolicy.MaxBackoff, retryPolicy.MinBackoff),
+		)
+	}
+	requestTimeout := time.Duration(int64Setting(config.RequestTimeout, "SOLACECLOUD_REQUEST_TIMEOUT", defaultRequestTimeout, path.Root("request_timeout"), &resp.Diagnostics)) * time.Second
+
+	requestsPerSecond := float64Setting(config.RequestsPerSecond, "SOLACECLOUD_REQUESTS_PER_SECOND", defaultRequestsPerSecond, path.Root("requests_per_second"), &resp.Diagnostics)
+	burst := int64Setting(config.Burst, "SOLACECLOUD_BURST", defaultBurst, path.Root("burst"), &resp.Diagnostics)
+
+	tflog.Debug(ctx, fmt.Sprintf("max_retries = %d, min_backoff = %s, max_backoff = %s, request_timeout = %s, requests_per_second = %g, burst = %d",
+		retryPolicy.MaxRetries, retryPolicy.MinBackoff, retryPolicy.MaxBackoff, requestTimeout, requestsPerSecond, burst))
+
+	transport, diags := newTransport(config)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -150,22 +333,34 @@ func (p *solaceCloudProvider) Configure(ctx context.Context, req provider.Config
 	//Create Solace Cloud API Client using the generated OpenAPIv3 code
 	///////////////////////////////////////////////
 
-	// Use the NewSecurityProviderBearerToken to pass the SC APIToken
-	tokenAuth, err := securityprovider.NewSecurityProviderBearerToken(apiToken)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to set the API_Token on the securityprovider.NewSecurityProviderBearerToken ",
-			"An unexpected error occurred when creating the SolaceCloud API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"Solace Cloud API Client Error: "+err.Error(),
-		)
+	// Both clients share the HTTP client, and with it the request timeout, the rate limit, the transport settings and
+	// the logging of the requests.
+	rateLimiter := shared.NewRateLimiter(requestsPerSecond, int(burst))
+	loggingTransport := &shared.LoggingTransport{Transport: transport}
+	tokenSource, diags := newTokenSource(ctx, config, &http.Client{Transport: loggingTransport, Timeout: requestTimeout})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	httpClient := &shared.RateLimitedDoer{
+		Doer: &http.Client{
+			Transport: &shared.BearerTokenTransport{Transport: loggingTransport, Source: tokenSource},
+			Timeout:   requestTimeout,
+		},
+		Limiter: rateLimiter,
+	}
+
+	if discover {
+		baseUrl, diags = discoverHomeCloud(ctx, baseUrl, httpClient)
+		resp.Diagnostics.Append(diags...)
+	}
+	tflog.Debug(ctx, fmt.Sprintf("base_url = %s", baseUrl))
 
 	//Create the Solace Cloud API Client we'll be using to make all the requests on this TF Provider
-	//Use tokenAuth.Intercept to set the Bearer Token before sending Requests
+	//The HTTP client sets the Bearer Token before sending Requests
 	apiClient, err := missioncontrol.NewClientWithResponses(
 		baseUrl,
-		missioncontrol.WithRequestEditorFn(tokenAuth.Intercept),
+		missioncontrol.WithHTTPClient(httpClient),
 		// Add a Request Editor to set the Content-Type to JSON for all requests
 		func(c *missioncontrol.Client) error {
 			c.RequestEditors = append(c.RequestEditors, func(ctx context.Context, req *http.Request) error {
@@ -177,7 +372,7 @@ func (p *solaceCloudProvider) Configure(ctx context.Context, req provider.Config
 		})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to set the API_Token on the securityprovider.NewSecurityProviderBearerToken ",
+			"Unable to create Mission Control API client",
 			"An unexpected error occurred when creating the SolaceCloud API client. "+
 				"If the error is not clear, please contact the provider developers.\n\n"+
 				"Solace Cloud API Client Error: "+err.Error(),
@@ -189,7 +384,7 @@ func (p *solaceCloudProvider) Configure(ctx context.Context, req provider.Config
 	// Create the Platform API client
 	platformClient, err := platform.NewClientWithResponses(
 		baseUrl,
-		platform.WithRequestEditorFn(tokenAuth.Intercept),
+		platform.WithHTTPClient(httpClient),
 		// Add a Request Editor to set the Content-Type to JSON for all requests
 		func(c *platform.Client) error {
 			c.RequestEditors = append(c.RequestEditors, func(ctx context.Context, req *http.Request) error {
@@ -208,17 +403,50 @@ func (p *solaceCloudProvider) Configure(ctx context.Context, req provider.Config
 		)
 	}
 
+	// The token is cached by now, the organization is read from it when it is a JWT.
+	apiToken, _ := tokenSource.Token(ctx)
+	organizationId := util.OrganizationIdFromToken(apiToken)
+
+	var permissions shared.Permissions
+	if runPreflight && !resp.Diagnostics.HasError() {
+		result, diags := preflight(ctx, platformClient, baseUrl, apiToken)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if organizationId == "" {
+			organizationId = result.OrganizationId
+		}
+		permissions = result.Permissions
+	}
+
+	// The defaults inherited by services that do not set their environment, datacenter or service class.
+	var defaultEnvironment *shared.DefaultEnvironment
+	if nameOrId := stringSetting(config.DefaultEnvironment, "SOLACECLOUD_DEFAULT_ENVIRONMENT", ""); nameOrId != "" {
+		defaultEnvironment = &shared.DefaultEnvironment{NameOrId: nameOrId}
+	}
+
 	//	Make the Solace Cloud API client & other config params available during DataSource and Resource as a shared.ProviderConfig
 	providerConfig := shared.ProviderConfig{
-		APIClient:          apiClient,
-		APIPollingInterval: apiPollingInterval,
-		PlatformClient:     platformClient,
+		APIClient:                apiClient,
+		APIPollingInterval:       apiPollingInterval,
+		DefaultDatacenterId:      stringSetting(config.DefaultDatacenterId, "SOLACECLOUD_DEFAULT_DATACENTER_ID", ""),
+		DefaultEnvironment:       defaultEnvironment,
+		DefaultServiceClassId:    stringSetting(config.DefaultServiceClassId, "SOLACECLOUD_DEFAULT_SERVICE_CLASS_ID", defaultServiceClassId),
+		OrganizationId:           organizationId,
+		Permissions:              permissions,
+		PlatformClient:           platformClient,
+		RateLimiter:              rateLimiter,
+		ReplaceOnImmutableChange: config.ReplaceOnImmutableChange.ValueBool(),
+		RetryPolicy:              retryPolicy,
+		ServiceClasses:           &shared.ServiceClassCache{},
 	}
 
 	// Make the TOKEN client available during DataSource and Resource
 	// type Configure methods.
 	resp.DataSourceData = providerConfig
 	resp.ResourceData = providerConfig
+	resp.EphemeralResourceData = providerConfig
 
 }
 
@@ -229,6 +457,13 @@ func (p *solaceCloudProvider) DataSources(_ context.Context) []func() datasource
 	}
 }
 
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *solaceCloudProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewServiceCredentialsEphemeralResource,
+	}
+}
+
 // Resources defines the resources implemented in the provider.
 func (p *solaceCloudProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
@@ -241,3 +476,180 @@ func (p *solaceCloudProvider) Resources(_ context.Context) []func() resource.Res
 	// Typeconst
 
 }
+
+// int64Setting returns the configured value of a provider attribute, else the value of its environment variable, else
+// the default value.
+func int64Setting(value types.Int64, envVar string, defaultValue int64, attributePath path.Path, diagnostics *diag.Diagnostics) int64 {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64()
+	}
+
+	envValue := os.Getenv(envVar)
+	if envValue == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseInt(envValue, 10, 64)
+	if err != nil || parsed < 0 {
+		diagnostics.AddAttributeError(
+			attributePath,
+			"Invalid Environment Variable",
+			fmt.Sprintf("The %s environment variable must be a whole number of at least 0, got %q.", envVar, envValue),
+		)
+		return defaultValue
+	}
+	return parsed
+}
+
+// stringSetting returns the configured value of a provider attribute, else the value of its environment variable, else
+// the default value.
+func stringSetting(value types.String, envVar string, defaultValue string) string {
+	if value.ValueString() != "" {
+		return value.ValueString()
+	}
+	if envValue := os.Getenv(envVar); envValue != "" {
+		return envValue
+	}
+	return defaultValue
+}
+
+// boolSetting returns the configured value of a provider attribute, else the value of its environment variable, else
+// the default value.
+func boolSetting(value types.Bool, envVar string, defaultValue bool, attributePath path.Path, diagnostics *diag.Diagnostics) bool {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueBool()
+	}
+
+	envValue := os.Getenv(envVar)
+	if envValue == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(envValue)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			attributePath,
+			"Invalid Environment Variable",
+			fmt.Sprintf("The %s environment variable must be true or false, got %q.", envVar, envValue),
+		)
+		return defaultValue
+	}
+	return parsed
+}
+
+// float64Setting returns the configured value of a provider attribute, else the value of its environment variable,
+// else the default value.
+func float64Setting(value types.Float64, envVar string, defaultValue float64, attributePath path.Path, diagnostics *diag.Diagnostics) float64 {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueFloat64()
+	}
+
+	envValue := os.Getenv(envVar)
+	if envValue == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseFloat(envValue, 64)
+	if err != nil || parsed < 0 {
+		diagnostics.AddAttributeError(
+			attributePath,
+			"Invalid Environment Variable",
+			fmt.Sprintf("The %s environment variable must be a number of at least 0, got %q.", envVar, envValue),
+		)
+		return defaultValue
+	}
+	return parsed
+}
+
+// newTransport builds the HTTP transport of the API clients from the proxy and TLS settings.
+func newTransport(config solaceCloudProviderModel) (*http.Transport, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	transportConfig := shared.TransportConfig{
+		ProxyURL:           config.ProxyURL.ValueString(),
+		CACertPEM:          []byte(config.CACertPEM.ValueString()),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+	caCertPath := path.Root("ca_cert_pem")
+	if caCertFile := config.CACertFile.ValueString(); caCertFile != "" {
+		caCertPath = path.Root("ca_cert_file")
+		caCertPEM, err := os.ReadFile(caCertFile)
+		if err != nil {
+			diagnostics.AddAttributeError(caCertPath, "Unable to Read CA Certificates", err.Error())
+			return nil, diagnostics
+		}
+		transportConfig.CACertPEM = caCertPEM
+	}
+
+	if transportConfig.InsecureSkipVerify {
+		diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"The TLS certificate of the API is not verified, the API token could be sent to anyone able to intercept "+
+				"the connection. Only use insecure_skip_verify with local stand-ins of the Solace Cloud API.",
+		)
+	}
+
+	transport, err := shared.NewTransport(transportConfig)
+	if err != nil {
+		attributePath := caCertPath
+		if errors.Is(err, shared.ErrInvalidProxyURL) {
+			attributePath = path.Root("proxy_url")
+		}
+		diagnostics.AddAttributeError(attributePath, "Invalid Transport Settings", err.Error())
+		return nil, diagnostics
+	}
+	return transport, diagnostics
+}
+
+// newTokenSource returns the source of the API token, from the first of api_token, api_token_file, api_token_command
+// and oauth2_client_credentials that is configured, or else from their environment variables. Tokens that are not
+// given directly are fetched straight away, so that a broken configuration fails here rather than on every request.
+func newTokenSource(ctx context.Context, config solaceCloudProviderModel, client shared.HTTPRequestDoer) (*shared.TokenSource, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	var source *shared.TokenSource
+	var sourcePath path.Path
+	switch {
+	case config.APIToken.ValueString() != "":
+		return shared.NewStaticTokenSource(config.APIToken.ValueString()), diagnostics
+	case config.APITokenFile.ValueString() != "":
+		source, sourcePath = shared.NewFileTokenSource(config.APITokenFile.ValueString()), path.Root("api_token_file")
+	case config.APITokenCommand.ValueString() != "":
+		source, sourcePath = shared.NewCommandTokenSource(config.APITokenCommand.ValueString()), path.Root("api_token_command")
+	case !config.OAuth2ClientCredentials.IsNull():
+		var credentials oauth2ClientCredentialsModel
+		diagnostics.Append(config.OAuth2ClientCredentials.As(ctx, &credentials, basetypes.ObjectAsOptions{})...)
+		var scopes []string
+		diagnostics.Append(credentials.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if diagnostics.HasError() {
+			return nil, diagnostics
+		}
+		source = shared.NewClientCredentialsTokenSource(shared.ClientCredentials{
+			TokenURL:     credentials.TokenURL.ValueString(),
+			ClientID:     credentials.ClientID.ValueString(),
+			ClientSecret: credentials.ClientSecret.ValueString(),
+			Scopes:       scopes,
+		}, client)
+		sourcePath = path.Root("oauth2_client_credentials")
+	case os.Getenv("SOLACECLOUD_API_TOKEN") != "":
+		return shared.NewStaticTokenSource(os.Getenv("SOLACECLOUD_API_TOKEN")), diagnostics
+	case os.Getenv("SOLACECLOUD_API_TOKEN_FILE") != "":
+		source, sourcePath = shared.NewFileTokenSource(os.Getenv("SOLACECLOUD_API_TOKEN_FILE")), path.Root("api_token_file")
+	case os.Getenv("SOLACECLOUD_API_TOKEN_COMMAND") != "":
+		source, sourcePath = shared.NewCommandTokenSource(os.Getenv("SOLACECLOUD_API_TOKEN_COMMAND")), path.Root("api_token_command")
+	default:
+		diagnostics.AddAttributeError(
+			path.Root("api_token"),
+			"Missing Solace Cloud API Token",
+			"The provider cannot create the API client as there is an unknown configuration value for the Solace API Token. "+
+				"Set one of api_token, api_token_file, api_token_command or oauth2_client_credentials in the configuration, "+
+				"or use the SOLACECLOUD_API_TOKEN, SOLACECLOUD_API_TOKEN_FILE or SOLACECLOUD_API_TOKEN_COMMAND environment variable. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+		return nil, diagnostics
+	}
+
+	if _, err := source.Token(ctx); err != nil {
+		diagnostics.AddAttributeError(sourcePath, "Unable to Get Solace Cloud API Token", err.Error())
+		return nil, diagnostics
+	}
+	return source, diagnostics
+}
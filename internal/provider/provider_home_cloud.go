@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"terraform-provider-solacecloud/internal/shared"
+	"terraform-provider-solacecloud/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultBaseURL is used when neither a base URL nor a home cloud is configured.
+const defaultBaseURL = "https://production-api.solace.cloud"
+
+// homeClouds are the base URLs of the REST API of each Solace Cloud home cloud, in the order they are tried when
+// discovering the home cloud of a token.
+var homeClouds = []struct {
+	name    string
+	baseURL string
+	aliases []string
+}{
+	{"us", "https://api.solace.cloud", []string{"usa", "america", "north-america"}},
+	{"eu", "https://api.solacecloud.eu", []string{"europe"}},
+	{"au", "https://api.solacecloud.com.au", []string{"australia"}},
+	{"sg", "https://api.solacecloud.sg", []string{"singapore"}},
+}
+
+// homeCloudNames lists the accepted home_cloud values, for the attribute validation and its errors.
+func homeCloudNames() []string {
+	var names []string
+	for _, homeCloud := range homeClouds {
+		names = append(names, homeCloud.name)
+		names = append(names, homeCloud.aliases...)
+	}
+	return names
+}
+
+// homeCloudBaseURL returns the base URL of a home cloud from its name or one of its aliases.
+func homeCloudBaseURL(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, homeCloud := range homeClouds {
+		if homeCloud.name == name || slices.Contains(homeCloud.aliases, name) {
+			return homeCloud.baseURL, true
+		}
+	}
+	return "", false
+}
+
+// normalizeBaseURL accepts the forms a base URL is commonly copied in, such as api.solace.cloud or
+// https://api.solace.cloud/api/v2/, and returns it as a scheme and host without a trailing slash.
+func normalizeBaseURL(baseURL string) (string, error) {
+	baseURL = strings.TrimSpace(baseURL)
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" || parsed.Host == "" {
+		return "", fmt.Errorf("%q is not an http or https URL", baseURL)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("%q cannot have a query or fragment", baseURL)
+	}
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/api/v2")
+	parsed.RawPath = ""
+	return strings.TrimRight(parsed.String(), "/"), nil
+}
+
+// resolveBaseURL returns the base URL from, in order, the base_url and home_cloud attributes (possibly set by a
+// profile), the SOLACECLOUD_BASE_URL and SOLACECLOUD_HOME_CLOUD environment variables, or the default base URL.
+func resolveBaseURL(config solaceCloudProviderModel) (string, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	baseURLPath, homeCloudPath := path.Root("base_url"), path.Root("home_cloud")
+	baseURL, homeCloud := config.BaseURL.ValueString(), config.HomeCloud.ValueString()
+	if baseURL == "" && homeCloud == "" {
+		baseURL, homeCloud = os.Getenv("SOLACECLOUD_BASE_URL"), os.Getenv("SOLACECLOUD_HOME_CLOUD")
+	}
+
+	switch {
+	case baseURL != "":
+		normalized, err := normalizeBaseURL(baseURL)
+		if err != nil {
+			diagnostics.AddAttributeError(baseURLPath, "Invalid Base URL", err.Error())
+		}
+		return normalized, diagnostics
+	case homeCloud != "":
+		homeCloudURL, ok := homeCloudBaseURL(homeCloud)
+		if !ok {
+			diagnostics.AddAttributeError(homeCloudPath, "Unknown Home Cloud",
+				fmt.Sprintf("%q is not a known home cloud, expected one of %s.", homeCloud, strings.Join(homeCloudNames(), ", ")))
+		}
+		return homeCloudURL, diagnostics
+	default:
+		return defaultBaseURL, diagnostics
+	}
+}
+
+// discoverHomeCloud checks that the base URL accepts the API token, and when it does not, tries the other home clouds
+// and returns the base URL of the first that does. The base URL is returned as is when no home cloud accepts the token
+// or the check fails, so that the API calls report the error.
+func discoverHomeCloud(ctx context.Context, baseURL string, httpClient shared.HTTPRequestDoer) (string, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	// The token was fetched when the provider was configured, renewing it each time a home cloud rejects it would only
+	// run the token command or request again for every home cloud probed.
+	ctx = shared.WithoutTokenRenewal(ctx)
+	if accepted, err := acceptsToken(ctx, baseURL, httpClient); err != nil || accepted {
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Not discovering the home cloud, the API token check failed: %s", err))
+		}
+		return baseURL, diagnostics
+	}
+
+	for _, homeCloud := range homeClouds {
+		if homeCloud.baseURL == baseURL {
+			continue
+		}
+		if accepted, err := acceptsToken(ctx, homeCloud.baseURL, httpClient); err == nil && accepted {
+			diagnostics.AddWarning(
+				"Home Cloud Discovered",
+				fmt.Sprintf("The API token was rejected by %s and accepted by home cloud %s, using %s. "+
+					"Set home_cloud = %q on the provider to use it straight away.", baseURL, homeCloud.name, homeCloud.baseURL, homeCloud.name),
+			)
+			return homeCloud.baseURL, diagnostics
+		}
+	}
+
+	diagnostics.AddWarning(
+		"Home Cloud Not Discovered",
+		fmt.Sprintf("The API token was rejected by %s and by every known home cloud. Check that the token is valid and has not expired.", baseURL),
+	)
+	return baseURL, diagnostics
+}
+
+// acceptsToken sends a cheap authenticated request to a base URL, and reports whether the token was not rejected. A
+// token lacking the permission of the request is still accepted.
+func acceptsToken(ctx context.Context, baseURL string, httpClient shared.HTTPRequestDoer) (bool, error) {
+	client, err := missioncontrol.NewClient(baseURL, missioncontrol.WithHTTPClient(httpClient))
+	if err != nil {
+		return false, err
+	}
+	resp, err := client.GetServiceClasses(ctx, &missioncontrol.GetServiceClassesParams{})
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode != http.StatusUnauthorized, nil
+}
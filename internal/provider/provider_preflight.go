@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"terraform-provider-solacecloud/internal/shared"
+	"terraform-provider-solacecloud/internal/util"
+	"terraform-provider-solacecloud/platform"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// preflightClient is the part of the platform API used by the preflight check.
+type preflightClient interface {
+	GetRolesWithResponse(ctx context.Context, reqEditors ...platform.RequestEditorFn) (*platform.GetRolesResponse, error)
+	GetUsersWithResponse(ctx context.Context, params *platform.GetUsersParams, reqEditors ...platform.RequestEditorFn) (*platform.GetUsersResponse, error)
+}
+
+// preflightResult is what the preflight check found out about the API token.
+type preflightResult struct {
+	OrganizationId string
+	Permissions    shared.Permissions
+}
+
+// preflight checks the API token before any resource runs. It fails on a token the API rejects, and resolves the
+// organization and the permissions of the token from the roles of the user who created it. The permissions are left
+// unknown, with a warning, when the token cannot read the roles or users of the organization.
+func preflight(ctx context.Context, client preflightClient, baseURL, token string) (preflightResult, diag.Diagnostics) {
+	var result preflightResult
+	var diagnostics diag.Diagnostics
+
+	rolesResp, err := client.GetRolesWithResponse(ctx)
+	if err != nil {
+		diagnostics.AddError(
+			"Preflight Check Failed",
+			fmt.Sprintf("The API token could not be checked against %s: %s", baseURL, err),
+		)
+		return result, diagnostics
+	}
+	switch rolesResp.StatusCode() {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		diagnostics.AddError(
+			"Invalid Solace Cloud API Token",
+			fmt.Sprintf("The API token was rejected by %s. Check that it has not expired or been revoked, and that "+
+				"base_url or home_cloud is the home cloud of its account.", baseURL),
+		)
+		return result, diagnostics
+	default:
+		diagnostics.Append(permissionsNotCheckedWarning("list the roles of the organization", "iam_org_roles:get", rolesResp.StatusCode())...)
+		return result, diagnostics
+	}
+
+	userId := util.UserIdFromToken(token)
+	if userId == "" {
+		diagnostics.AddWarning(
+			"API Token Permissions Not Checked",
+			"The API token does not tell which user created it, its permissions are checked by each API call instead.",
+		)
+		return result, diagnostics
+	}
+	usersResp, err := client.GetUsersWithResponse(ctx, &platform.GetUsersParams{Ids: &[]string{userId}})
+	if err != nil {
+		diagnostics.AddError(
+			"Preflight Check Failed",
+			fmt.Sprintf("The API token could not be checked against %s: %s", baseURL, err),
+		)
+		return result, diagnostics
+	}
+	if usersResp.StatusCode() != http.StatusOK {
+		diagnostics.Append(permissionsNotCheckedWarning("read the user who created it", "iam_users:get", usersResp.StatusCode())...)
+		return result, diagnostics
+	}
+	var users platform.UsersResponseEnvelope
+	if err := json.Unmarshal(usersResp.Body, &users); err != nil || users.Data == nil || len(*users.Data) != 1 {
+		diagnostics.AddWarning(
+			"API Token Permissions Not Checked",
+			fmt.Sprintf("The user %s who created the API token was not found, its permissions are checked by each API call instead.", userId),
+		)
+		return result, diagnostics
+	}
+	user := (*users.Data)[0]
+
+	if user.OrganizationId != nil {
+		result.OrganizationId = *user.OrganizationId
+	}
+	var roleIds []string
+	if user.Roles != nil {
+		roleIds = *user.Roles
+	}
+	result.Permissions = rolePermissions(rolesResp.JSON200, roleIds)
+	tflog.Debug(ctx, fmt.Sprintf("Preflight check: organization %s, %d permissions granted by roles %s",
+		result.OrganizationId, len(result.Permissions), strings.Join(roleIds, ", ")))
+	return result, diagnostics
+}
+
+// rolePermissions returns the permissions granted by the given roles, by id and by name.
+func rolePermissions(roles *platform.RolesResponseEnvelope, roleIds []string) shared.Permissions {
+	permissions := shared.Permissions{}
+	if roles == nil || roles.Data == nil {
+		return permissions
+	}
+	for _, role := range *roles.Data {
+		if role.Id == nil || role.Permissions == nil || !slices.Contains(roleIds, *role.Id) {
+			continue
+		}
+		for _, permission := range *role.Permissions {
+			if permission.Id != nil {
+				permissions[*permission.Id] = true
+			}
+			if permission.Name != nil {
+				permissions[*permission.Name] = true
+			}
+		}
+	}
+	return permissions
+}
+
+func permissionsNotCheckedWarning(action, permission string, statusCode int) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	diagnostics.AddWarning(
+		"API Token Permissions Not Checked",
+		fmt.Sprintf("The API token could not %s (HTTP %d), which needs the %s permission. Its permissions are "+
+			"checked by each API call instead.", action, statusCode, permission),
+	)
+	return diagnostics
+}
@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultProfileFile is where profiles are read from, relative to the home directory, unless SOLACECLOUD_CONFIG_FILE
+// points to another file.
+const defaultProfileFile = ".solacecloud/config"
+
+// profileSetting is a key = value line of a profile.
+type profileSetting struct {
+	key   string
+	value string
+	line  int
+}
+
+// profileFile returns the path of the file holding the profiles.
+func profileFile() (string, error) {
+	if file := os.Getenv("SOLACECLOUD_CONFIG_FILE"); file != "" {
+		return expandHome(file)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, defaultProfileFile), nil
+}
+
+// readProfile reads the settings of a profile from an INI style file, where each profile is a [name] section of
+// key = value lines. Lines starting with # or ; are comments.
+func readProfile(file, name string) ([]profileSetting, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var settings []profileSetting
+	found, inProfile := false, false
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";"):
+		case strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			inProfile = strings.TrimSpace(text[1:len(text)-1]) == name
+			found = found || inProfile
+		case !inProfile:
+		default:
+			key, value, ok := strings.Cut(text, "=")
+			if !ok {
+				return nil, fmt.Errorf("%s:%d: expected key = value", file, line)
+			}
+			settings = append(settings, profileSetting{strings.TrimSpace(key), unquote(strings.TrimSpace(value)), line})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("profile %q not found in %s", name, file)
+	}
+	return settings, nil
+}
+
+func unquote(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
+
+// expandHome replaces a leading ~ of a path with the home directory.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// loadProfile applies the named profile to the configuration.
+func loadProfile(config *solaceCloudProviderModel, name string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	file, err := profileFile()
+	if err == nil {
+		var settings []profileSetting
+		if settings, err = readProfile(file, name); err == nil {
+			err = applyProfile(config, file, settings)
+		}
+	}
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("profile"), "Invalid Profile", err.Error())
+	}
+	return diagnostics
+}
+
+// profileEnvVars maps the settings of a profile to the environment variables that take precedence over them. A token
+// environment variable replaces every token setting of the profile, and SOLACECLOUD_BASE_URL and
+// SOLACECLOUD_HOME_CLOUD replace both base_url and home_cloud, as they do for the attributes.
+var profileEnvVars = map[string][]string{
+	"base_url":          {"SOLACECLOUD_BASE_URL", "SOLACECLOUD_HOME_CLOUD"},
+	"home_cloud":        {"SOLACECLOUD_BASE_URL", "SOLACECLOUD_HOME_CLOUD"},
+	"api_token":         {"SOLACECLOUD_API_TOKEN", "SOLACECLOUD_API_TOKEN_FILE", "SOLACECLOUD_API_TOKEN_COMMAND"},
+	"api_token_file":    {"SOLACECLOUD_API_TOKEN", "SOLACECLOUD_API_TOKEN_FILE", "SOLACECLOUD_API_TOKEN_COMMAND"},
+	"api_token_command": {"SOLACECLOUD_API_TOKEN", "SOLACECLOUD_API_TOKEN_FILE", "SOLACECLOUD_API_TOKEN_COMMAND"},
+
+	"discover_home_cloud":      {"SOLACECLOUD_DISCOVER_HOME_CLOUD"},
+	"preflight":                {"SOLACECLOUD_PREFLIGHT"},
+	"max_retries":              {"SOLACECLOUD_MAX_RETRIES"},
+	"min_backoff":              {"SOLACECLOUD_MIN_BACKOFF"},
+	"max_backoff":              {"SOLACECLOUD_MAX_BACKOFF"},
+	"request_timeout":          {"SOLACECLOUD_REQUEST_TIMEOUT"},
+	"requests_per_second":      {"SOLACECLOUD_REQUESTS_PER_SECOND"},
+	"burst":                    {"SOLACECLOUD_BURST"},
+	"default_environment":      {"SOLACECLOUD_DEFAULT_ENVIRONMENT"},
+	"default_datacenter_id":    {"SOLACECLOUD_DEFAULT_DATACENTER_ID"},
+	"default_service_class_id": {"SOLACECLOUD_DEFAULT_SERVICE_CLASS_ID"},
+}
+
+// setInEnvironment reports whether an environment variable taking precedence over the setting of a profile is set.
+func setInEnvironment(key string) bool {
+	for _, envVar := range profileEnvVars[key] {
+		if os.Getenv(envVar) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyProfile sets the attributes the configuration leaves null from the settings of a profile, unless their
+// environment variables are set: the configuration comes first, then the environment, then the profile. A token source
+// or CA certificate set in the configuration replaces the ones of the profile, rather than conflicting with them, and
+// so does a base URL or home cloud.
+func applyProfile(config *solaceCloudProviderModel, file string, settings []profileSetting) error {
+	tokenConfigured := !config.APIToken.IsNull() || !config.APITokenFile.IsNull() || !config.APITokenCommand.IsNull() ||
+		!config.OAuth2ClientCredentials.IsNull()
+	caCertConfigured := !config.CACertPEM.IsNull() || !config.CACertFile.IsNull()
+	baseURLConfigured := !config.BaseURL.IsNull() || !config.HomeCloud.IsNull()
+
+	stringSettings := map[string]*types.String{
+		"base_url":          &config.BaseURL,
+		"home_cloud":        &config.HomeCloud,
+		"api_token":         &config.APIToken,
+		"api_token_file":    &config.APITokenFile,
+		"api_token_command": &config.APITokenCommand,
+		"proxy_url":         &config.ProxyURL,
+		"ca_cert_file":      &config.CACertFile,
+
+		"default_environment":      &config.DefaultEnvironment,
+		"default_datacenter_id":    &config.DefaultDatacenterId,
+		"default_service_class_id": &config.DefaultServiceClassId,
+	}
+	int64Settings := map[string]*types.Int64{
+		"api_polling_interval": &config.APIPollingInterval,
+		"max_retries":          &config.MaxRetries,
+		"min_backoff":          &config.MinBackoff,
+		"max_backoff":          &config.MaxBackoff,
+		"request_timeout":      &config.RequestTimeout,
+		"burst":                &config.Burst,
+	}
+	float64Settings := map[string]*types.Float64{
+		"requests_per_second": &config.RequestsPerSecond,
+	}
+	boolSettings := map[string]*types.Bool{
+		"replace_on_immutable_change": &config.ReplaceOnImmutableChange,
+		"discover_home_cloud":         &config.DiscoverHomeCloud,
+		"preflight":                   &config.Preflight,
+	}
+
+	profileTokens := 0
+	for _, setting := range settings {
+		switch setting.key {
+		case "api_token", "api_token_file", "api_token_command":
+			profileTokens++
+			if tokenConfigured {
+				continue
+			}
+		case "ca_cert_file":
+			if caCertConfigured {
+				continue
+			}
+		case "base_url", "home_cloud":
+			if baseURLConfigured {
+				continue
+			}
+		}
+		if setInEnvironment(setting.key) {
+			continue
+		}
+
+		var err error
+		switch {
+		case stringSettings[setting.key] != nil:
+			if value := stringSettings[setting.key]; value.IsNull() {
+				if setting.key == "api_token_file" || setting.key == "ca_cert_file" {
+					setting.value, err = expandHome(setting.value)
+				}
+				*value = types.StringValue(setting.value)
+			}
+		case int64Settings[setting.key] != nil:
+			var parsed int64
+			if parsed, err = strconv.ParseInt(setting.value, 10, 64); err == nil && int64Settings[setting.key].IsNull() {
+				*int64Settings[setting.key] = types.Int64Value(parsed)
+			}
+		case float64Settings[setting.key] != nil:
+			var parsed float64
+			if parsed, err = strconv.ParseFloat(setting.value, 64); err == nil && float64Settings[setting.key].IsNull() {
+				*float64Settings[setting.key] = types.Float64Value(parsed)
+			}
+		case boolSettings[setting.key] != nil:
+			var parsed bool
+			if parsed, err = strconv.ParseBool(setting.value); err == nil && boolSettings[setting.key].IsNull() {
+				*boolSettings[setting.key] = types.BoolValue(parsed)
+			}
+		default:
+			return fmt.Errorf("%s:%d: unknown setting %q", file, setting.line, setting.key)
+		}
+		if err != nil {
+			return fmt.Errorf("%s:%d: invalid value for %s: %w", file, setting.line, setting.key, err)
+		}
+	}
+
+	if profileTokens > 1 {
+		return fmt.Errorf("%s: only one of api_token, api_token_file and api_token_command can be set in a profile", file)
+	}
+	return nil
+}
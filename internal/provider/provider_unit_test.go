@@ -2,9 +2,26 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"terraform-provider-solacecloud/internal/shared"
+	"terraform-provider-solacecloud/missioncontrol"
+	"terraform-provider-solacecloud/platform"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
@@ -60,13 +77,7 @@ func TestProviderBaseURLConfiguration(t *testing.T) {
 			// Create configuration based on test case
 			configValue := tftypes.NewValue(tftypes.String, tc.configValue)
 			// Create configuration object
-			config := tftypes.NewValue(tftypes.Object{
-				AttributeTypes: map[string]tftypes.Type{
-					"base_url":             tftypes.String,
-					"api_token":            tftypes.String,
-					"api_polling_interval": tftypes.Number,
-				},
-			}, map[string]tftypes.Value{
+			config := providerConfigValue(schemaResp.Schema, map[string]tftypes.Value{
 				"base_url":             configValue,
 				"api_token":            tftypes.NewValue(tftypes.String, "test-token"),
 				"api_polling_interval": tftypes.NewValue(tftypes.Number, 30),
@@ -114,6 +125,665 @@ func TestProviderBaseURLConfiguration(t *testing.T) {
 	}
 }
 
+func TestNormalizeBaseURL(t *testing.T) {
+	for value, expected := range map[string]string{
+		"https://api.solace.cloud":           "https://api.solace.cloud",
+		"https://api.solace.cloud/":          "https://api.solace.cloud",
+		" api.solacecloud.eu ":               "https://api.solacecloud.eu",
+		"https://api.solace.cloud/api/v2/":   "https://api.solace.cloud",
+		"http://localhost:8080":              "http://localhost:8080",
+		"https://gateway.example.com/solace": "https://gateway.example.com/solace",
+	} {
+		if normalized, err := normalizeBaseURL(value); err != nil || normalized != expected {
+			t.Errorf("%q: expected %s, got %s, %v", value, expected, normalized, err)
+		}
+	}
+
+	for _, value := range []string{"ftp://api.solace.cloud", "https://", "https://api.solace.cloud?org=1"} {
+		if _, err := normalizeBaseURL(value); err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
+
+func TestProviderHomeCloud(t *testing.T) {
+	t.Setenv("SOLACECLOUD_BASE_URL", "")
+	t.Setenv("SOLACECLOUD_HOME_CLOUD", "")
+	serverOf := func(providerConfig shared.ProviderConfig) string {
+		return providerConfig.APIClient.ClientInterface.(*missioncontrol.Client).Server
+	}
+
+	for _, tc := range []struct {
+		name     string
+		values   map[string]tftypes.Value
+		env      map[string]string
+		expected string
+	}{
+		{name: "home_cloud", values: map[string]tftypes.Value{"home_cloud": tftypes.NewValue(tftypes.String, "eu")}, expected: "https://api.solacecloud.eu/"},
+		{name: "home_cloud alias", values: map[string]tftypes.Value{"home_cloud": tftypes.NewValue(tftypes.String, "Australia")}, expected: "https://api.solacecloud.com.au/"},
+		{name: "SOLACECLOUD_BASE_URL", env: map[string]string{"SOLACECLOUD_BASE_URL": "api.solacecloud.sg/api/v2"}, expected: "https://api.solacecloud.sg/"},
+		{name: "SOLACECLOUD_HOME_CLOUD", env: map[string]string{"SOLACECLOUD_HOME_CLOUD": "us"}, expected: "https://api.solace.cloud/"},
+		{
+			name:     "attribute overrides environment variable",
+			values:   map[string]tftypes.Value{"base_url": tftypes.NewValue(tftypes.String, "https://api.solace.cloud/")},
+			env:      map[string]string{"SOLACECLOUD_HOME_CLOUD": "eu"},
+			expected: "https://api.solace.cloud/",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			values := map[string]tftypes.Value{"api_token": tftypes.NewValue(tftypes.String, "test-token")}
+			maps.Copy(values, tc.values)
+			providerConfig, resp := configureProvider(t, values)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if server := serverOf(providerConfig); server != tc.expected {
+				t.Errorf("expected base URL %s, got %s", tc.expected, server)
+			}
+		})
+	}
+
+	t.Run("unknown home cloud", func(t *testing.T) {
+		t.Setenv("SOLACECLOUD_HOME_CLOUD", "mars")
+		_, resp := configureProvider(t, map[string]tftypes.Value{"api_token": tftypes.NewValue(tftypes.String, "test-token")})
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Unknown Home Cloud" {
+			t.Errorf("expected an Unknown Home Cloud error, got %v", resp.Diagnostics)
+		}
+	})
+}
+
+func TestProviderDiscoverHomeCloud(t *testing.T) {
+	// Each server only accepts its own token.
+	newHomeCloud := func(token string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusForbidden)
+		}))
+	}
+	us, eu := newHomeCloud("us-token"), newHomeCloud("eu-token")
+	defer us.Close()
+	defer eu.Close()
+
+	knownHomeClouds := homeClouds
+	defer func() { homeClouds = knownHomeClouds }()
+	homeClouds = slices.Clone(homeClouds[:2])
+	homeClouds[0].baseURL, homeClouds[1].baseURL = us.URL, eu.URL
+
+	for _, tc := range []struct {
+		name     string
+		token    string
+		expected string
+		warning  string
+	}{
+		{name: "token accepted", token: "us-token", expected: us.URL + "/"},
+		{name: "token of another home cloud", token: "eu-token", expected: eu.URL + "/", warning: "Home Cloud Discovered"},
+		{name: "token rejected everywhere", token: "revoked-token", expected: us.URL + "/", warning: "Home Cloud Not Discovered"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+				"api_token":           tftypes.NewValue(tftypes.String, tc.token),
+				"base_url":            tftypes.NewValue(tftypes.String, us.URL),
+				"discover_home_cloud": tftypes.NewValue(tftypes.Bool, true),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if server := providerConfig.APIClient.ClientInterface.(*missioncontrol.Client).Server; server != tc.expected {
+				t.Errorf("expected base URL %s, got %s", tc.expected, server)
+			}
+			warnings := resp.Diagnostics.Warnings()
+			if tc.warning == "" && len(warnings) > 0 || tc.warning != "" && (len(warnings) != 1 || warnings[0].Summary() != tc.warning) {
+				t.Errorf("expected warning %q, got %v", tc.warning, warnings)
+			}
+		})
+	}
+
+	t.Run("token fetched once", func(t *testing.T) {
+		fetches := filepath.Join(t.TempDir(), "fetches")
+		_, resp := configureProvider(t, map[string]tftypes.Value{
+			"api_token_command":   tftypes.NewValue(tftypes.String, fmt.Sprintf("echo fetch >> %s && echo revoked-token", fetches)),
+			"base_url":            tftypes.NewValue(tftypes.String, us.URL),
+			"discover_home_cloud": tftypes.NewValue(tftypes.Bool, true),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		content, err := os.ReadFile(fetches)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count := strings.Count(string(content), "fetch"); count != 1 {
+			t.Errorf("expected the token to be fetched once while probing the home clouds, got %d fetches", count)
+		}
+	})
+}
+
+func TestProviderPreflight(t *testing.T) {
+	token := func(claims string) string {
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch auth := r.Header.Get("Authorization"); {
+		case strings.Contains(auth, "revoked"):
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"message":"Unauthorized"}`)
+		case strings.Contains(auth, "unprivileged"):
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"message":"Forbidden"}`)
+		case r.URL.Path == "/api/v2/platform/roles":
+			_, _ = io.WriteString(w, `{"data":[
+				{"id":"mission-control-viewer","permissions":[{"id":"services:view","name":"services:view"}]},
+				{"id":"mission-control-editor","permissions":[{"id":"services:post"},{"id":"services:put"}]}]}`)
+		case r.URL.Path == "/api/v2/platform/users" && r.URL.Query().Get("ids") == "user-id":
+			_, _ = io.WriteString(w, `{"data":[{"id":"user-id","organizationId":"org-id","roles":["mission-control-viewer"]}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message":"Not Found"}`)
+		}
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name                string
+		token               string
+		error               string
+		warning             string
+		expectedOrg         string
+		expectedPermissions shared.Permissions
+	}{
+		{
+			name:                "permissions of the token",
+			token:               token(`{"sub":"user-id"}`),
+			expectedOrg:         "org-id",
+			expectedPermissions: shared.Permissions{"services:view": true},
+		},
+		{name: "organization of the token", token: token(`{"org":"token-org","sub":"user-id"}`), expectedOrg: "token-org",
+			expectedPermissions: shared.Permissions{"services:view": true}},
+		{name: "invalid token", token: "revoked-token", error: "Invalid Solace Cloud API Token"},
+		{name: "token cannot read roles", token: "unprivileged-token", warning: "API Token Permissions Not Checked"},
+		{name: "token without user", token: "opaque-token", warning: "API Token Permissions Not Checked"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+				"api_token": tftypes.NewValue(tftypes.String, tc.token),
+				"base_url":  tftypes.NewValue(tftypes.String, server.URL),
+				"preflight": tftypes.NewValue(tftypes.Bool, true),
+			})
+			errors, warnings := resp.Diagnostics.Errors(), resp.Diagnostics.Warnings()
+			if tc.error == "" && len(errors) > 0 || tc.error != "" && (len(errors) != 1 || errors[0].Summary() != tc.error) {
+				t.Fatalf("expected error %q, got %v", tc.error, errors)
+			}
+			if tc.warning == "" && len(warnings) > 0 || tc.warning != "" && (len(warnings) != 1 || warnings[0].Summary() != tc.warning) {
+				t.Errorf("expected warning %q, got %v", tc.warning, warnings)
+			}
+			if tc.error != "" {
+				return
+			}
+			if providerConfig.OrganizationId != tc.expectedOrg {
+				t.Errorf("expected organization %q, got %q", tc.expectedOrg, providerConfig.OrganizationId)
+			}
+			if !maps.Equal(providerConfig.Permissions, tc.expectedPermissions) {
+				t.Errorf("expected permissions %v, got %v", tc.expectedPermissions, providerConfig.Permissions)
+			}
+		})
+	}
+
+	// Without preflight, nothing is checked and every permission is assumed to be granted.
+	providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, "revoked-token"),
+		"base_url":  tftypes.NewValue(tftypes.String, server.URL),
+	})
+	if resp.Diagnostics.HasError() || providerConfig.Permissions != nil {
+		t.Errorf("expected no preflight check, got %v and permissions %v", resp.Diagnostics, providerConfig.Permissions)
+	}
+}
+
+func TestProviderServiceDefaults(t *testing.T) {
+	providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, "token"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if providerConfig.DefaultDatacenterId != "" || providerConfig.DefaultEnvironment != nil || providerConfig.DefaultServiceClassId != "DEVELOPER" {
+		t.Errorf("expected only the DEVELOPER service class default, got %q, %v, %q",
+			providerConfig.DefaultDatacenterId, providerConfig.DefaultEnvironment, providerConfig.DefaultServiceClassId)
+	}
+
+	t.Setenv("SOLACECLOUD_DEFAULT_DATACENTER_ID", "aws-ca-central-1a")
+	t.Setenv("SOLACECLOUD_DEFAULT_ENVIRONMENT", "Development")
+	providerConfig, resp = configureProvider(t, map[string]tftypes.Value{
+		"api_token":                tftypes.NewValue(tftypes.String, "token"),
+		"default_environment":      tftypes.NewValue(tftypes.String, "Production"),
+		"default_service_class_id": tftypes.NewValue(tftypes.String, "ENTERPRISE_250_STANDALONE"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if providerConfig.DefaultDatacenterId != "aws-ca-central-1a" || providerConfig.DefaultEnvironment.NameOrId != "Production" ||
+		providerConfig.DefaultServiceClassId != "ENTERPRISE_250_STANDALONE" {
+		t.Errorf("expected the configured defaults, got %q, %q, %q",
+			providerConfig.DefaultDatacenterId, providerConfig.DefaultEnvironment.NameOrId, providerConfig.DefaultServiceClassId)
+	}
+}
+
+// providerConfigValue builds a provider configuration with the given values, the other attributes are null.
+func providerConfigValue(s schema.Schema, values map[string]tftypes.Value) tftypes.Value {
+	objectType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+// configureProvider runs Configure with the given configuration values.
+func configureProvider(t *testing.T, values map[string]tftypes.Value) (shared.ProviderConfig, *provider.ConfigureResponse) {
+	t.Helper()
+	p := &solaceCloudProvider{version: "test"}
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(context.Background(), provider.ConfigureRequest{
+		Config: tfsdk.Config{Raw: providerConfigValue(schemaResp.Schema, values), Schema: schemaResp.Schema},
+	}, resp)
+
+	providerConfig, _ := resp.ResourceData.(shared.ProviderConfig)
+	return providerConfig, resp
+}
+
+func TestProviderRetryConfiguration(t *testing.T) {
+	token := map[string]tftypes.Value{"api_token": tftypes.NewValue(tftypes.String, "test-token")}
+
+	t.Run("defaults", func(t *testing.T) {
+		providerConfig, resp := configureProvider(t, token)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if providerConfig.RetryPolicy != shared.DefaultRetryPolicy() {
+			t.Errorf("expected the default retry policy, got %+v", providerConfig.RetryPolicy)
+		}
+		if timeout := providerConfig.APIClient.ClientInterface.(*missioncontrol.Client).Client.(*shared.RateLimitedDoer).Doer.(*http.Client).Timeout; timeout != time.Minute {
+			t.Errorf("expected a request timeout of 1m0s, got %s", timeout)
+		}
+	})
+
+	t.Run("environment variables", func(t *testing.T) {
+		t.Setenv("SOLACECLOUD_MAX_RETRIES", "10")
+		t.Setenv("SOLACECLOUD_MIN_BACKOFF", "2")
+		t.Setenv("SOLACECLOUD_MAX_BACKOFF", "120")
+		t.Setenv("SOLACECLOUD_REQUEST_TIMEOUT", "300")
+		providerConfig, resp := configureProvider(t, token)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		expected := shared.RetryPolicy{MaxRetries: 10, MinBackoff: 2 * time.Second, MaxBackoff: 2 * time.Minute}
+		if providerConfig.RetryPolicy != expected {
+			t.Errorf("expected retry policy %+v, got %+v", expected, providerConfig.RetryPolicy)
+		}
+		if timeout := providerConfig.PlatformClient.ClientInterface.(*platform.Client).Client.(*shared.RateLimitedDoer).Doer.(*http.Client).Timeout; timeout != 5*time.Minute {
+			t.Errorf("expected a request timeout of 5m0s, got %s", timeout)
+		}
+	})
+
+	t.Run("attributes override environment variables", func(t *testing.T) {
+		t.Setenv("SOLACECLOUD_MAX_RETRIES", "10")
+		providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+			"api_token":   tftypes.NewValue(tftypes.String, "test-token"),
+			"max_retries": tftypes.NewValue(tftypes.Number, 0),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if providerConfig.RetryPolicy.MaxRetries != 0 {
+			t.Errorf("expected max_retries 0, got %d", providerConfig.RetryPolicy.MaxRetries)
+		}
+	})
+
+	t.Run("invalid environment variable", func(t *testing.T) {
+		t.Setenv("SOLACECLOUD_REQUEST_TIMEOUT", "1m")
+		_, resp := configureProvider(t, token)
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Invalid Environment Variable" {
+			t.Errorf("expected an Invalid Environment Variable error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("min_backoff above max_backoff", func(t *testing.T) {
+		_, resp := configureProvider(t, map[string]tftypes.Value{
+			"api_token":   tftypes.NewValue(tftypes.String, "test-token"),
+			"min_backoff": tftypes.NewValue(tftypes.Number, 60),
+			"max_backoff": tftypes.NewValue(tftypes.Number, 10),
+		})
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Invalid Backoff" {
+			t.Errorf("expected an Invalid Backoff error, got %v", resp.Diagnostics)
+		}
+	})
+}
+
+func TestProviderRateLimitConfiguration(t *testing.T) {
+	t.Setenv("SOLACECLOUD_REQUESTS_PER_SECOND", "2.5")
+	providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, "test-token"),
+		"burst":     tftypes.NewValue(tftypes.Number, 5),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	limiter := providerConfig.RateLimiter
+	if limiter == nil {
+		t.Fatal("expected a rate limiter")
+	}
+	// Both clients go through the same limiter.
+	for name, doer := range map[string]missioncontrol.HttpRequestDoer{
+		"missioncontrol": providerConfig.APIClient.ClientInterface.(*missioncontrol.Client).Client,
+		"platform":       providerConfig.PlatformClient.ClientInterface.(*platform.Client).Client,
+	} {
+		if rateLimited, ok := doer.(*shared.RateLimitedDoer); !ok || rateLimited.Limiter != limiter {
+			t.Errorf("expected the %s client to use the shared rate limiter, got %T", name, doer)
+		}
+	}
+}
+
+func TestProviderTransportConfiguration(t *testing.T) {
+	transportOf := func(providerConfig shared.ProviderConfig) *http.Transport {
+		doer := providerConfig.APIClient.ClientInterface.(*missioncontrol.Client).Client
+		bearer := doer.(*shared.RateLimitedDoer).Doer.(*http.Client).Transport.(*shared.BearerTokenTransport)
+		transport, _ := bearer.Transport.(*shared.LoggingTransport).Transport.(*http.Transport)
+		return transport
+	}
+
+	t.Run("proxy and CA certificate file", func(t *testing.T) {
+		caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+		if err := os.WriteFile(caCertFile, []byte(testCACertPEM), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+			"api_token":    tftypes.NewValue(tftypes.String, "test-token"),
+			"proxy_url":    tftypes.NewValue(tftypes.String, "http://proxy.example.com:3128"),
+			"ca_cert_file": tftypes.NewValue(tftypes.String, caCertFile),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+
+		transport := transportOf(providerConfig)
+		if transport == nil || transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs == nil {
+			t.Fatalf("expected a transport trusting the CA certificate, got %v", transport)
+		}
+		request, _ := http.NewRequest(http.MethodGet, "https://api.solace.cloud", nil)
+		if proxyURL, _ := transport.Proxy(request); proxyURL == nil || proxyURL.Host != "proxy.example.com:3128" {
+			t.Errorf("expected requests to go through the proxy, got %v", proxyURL)
+		}
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+			"api_token":            tftypes.NewValue(tftypes.String, "test-token"),
+			"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true),
+		})
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+			t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+		}
+		if transport := transportOf(providerConfig); !transport.TLSClientConfig.InsecureSkipVerify {
+			t.Error("expected the TLS certificate verification to be disabled")
+		}
+	})
+
+	for _, tc := range []struct {
+		name   string
+		values map[string]tftypes.Value
+		path   string
+	}{
+		{"invalid proxy_url", map[string]tftypes.Value{"proxy_url": tftypes.NewValue(tftypes.String, "proxy.example.com")}, "proxy_url"},
+		{"invalid ca_cert_pem", map[string]tftypes.Value{"ca_cert_pem": tftypes.NewValue(tftypes.String, "not a certificate")}, "ca_cert_pem"},
+		{"missing ca_cert_file", map[string]tftypes.Value{"ca_cert_file": tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing.pem"))}, "ca_cert_file"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.values["api_token"] = tftypes.NewValue(tftypes.String, "test-token")
+			_, resp := configureProvider(t, tc.values)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an error")
+			}
+			if diagnostic, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath); !ok || !diagnostic.Path().Equal(path.Root(tc.path)) {
+				t.Errorf("expected an error on %s, got %v", tc.path, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProviderTokenConfiguration(t *testing.T) {
+	for _, name := range []string{"SOLACECLOUD_API_TOKEN", "SOLACECLOUD_API_TOKEN_FILE", "SOLACECLOUD_API_TOKEN_COMMAND"} {
+		t.Setenv(name, "")
+	}
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			_, _ = io.WriteString(w, `{"access_token":"oauth-token","expires_in":3600}`)
+			return
+		}
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		values   map[string]tftypes.Value
+		env      map[string]string
+		expected string
+	}{
+		{name: "api_token_file", values: map[string]tftypes.Value{"api_token_file": tftypes.NewValue(tftypes.String, tokenFile)}, expected: "file-token"},
+		{name: "api_token_command", values: map[string]tftypes.Value{"api_token_command": tftypes.NewValue(tftypes.String, "echo command-token")}, expected: "command-token"},
+		{name: "environment variable", values: map[string]tftypes.Value{}, env: map[string]string{"SOLACECLOUD_API_TOKEN_COMMAND": "echo env-token"}, expected: "env-token"},
+		{
+			name: "oauth2_client_credentials",
+			values: map[string]tftypes.Value{"oauth2_client_credentials": tftypes.NewValue(oauth2ClientCredentialsType, map[string]tftypes.Value{
+				"token_url":     tftypes.NewValue(tftypes.String, server.URL+"/oauth2/token"),
+				"client_id":     tftypes.NewValue(tftypes.String, "terraform"),
+				"client_secret": tftypes.NewValue(tftypes.String, "secret"),
+				"scopes":        tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			})},
+			expected: "oauth-token",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			tc.values["base_url"] = tftypes.NewValue(tftypes.String, server.URL)
+			providerConfig, resp := configureProvider(t, tc.values)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			authorization = ""
+			if _, err := providerConfig.APIClient.GetServiceWithResponse(context.Background(), "id", nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if authorization != "Bearer "+tc.expected {
+				t.Errorf("expected the token %s, got %q", tc.expected, authorization)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name   string
+		values map[string]tftypes.Value
+		path   string
+	}{
+		{name: "missing token", values: map[string]tftypes.Value{}, path: "api_token"},
+		{name: "failing command", values: map[string]tftypes.Value{"api_token_command": tftypes.NewValue(tftypes.String, "exit 1")}, path: "api_token_command"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, resp := configureProvider(t, tc.values)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an error")
+			}
+			if diagnostic, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath); !ok || !diagnostic.Path().Equal(path.Root(tc.path)) {
+				t.Errorf("expected an error on %s, got %v", tc.path, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProviderProfile(t *testing.T) {
+	for _, name := range []string{"SOLACECLOUD_API_TOKEN", "SOLACECLOUD_API_TOKEN_FILE", "SOLACECLOUD_API_TOKEN_COMMAND", "SOLACECLOUD_PROFILE"} {
+		t.Setenv(name, "")
+	}
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	configFile := filepath.Join(t.TempDir(), "config")
+	config := fmt.Sprintf(`# Solace Cloud profiles
+[eu]
+base_url = %s
+api_token_command = echo eu-token
+api_polling_interval = 10
+max_retries = 7
+
+[us]
+base_url = "%s"
+api_token = us-token
+unknown_setting = true
+`, server.URL, server.URL)
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SOLACECLOUD_CONFIG_FILE", configFile)
+
+	request := func(t *testing.T, providerConfig shared.ProviderConfig) string {
+		t.Helper()
+		authorization = ""
+		if _, err := providerConfig.APIClient.GetServiceWithResponse(context.Background(), "id", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return authorization
+	}
+
+	t.Run("profile attribute", func(t *testing.T) {
+		providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+			"profile": tftypes.NewValue(tftypes.String, "eu"),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if providerConfig.APIPollingInterval != 10 || providerConfig.RetryPolicy.MaxRetries != 7 {
+			t.Errorf("expected the settings of the profile, got api_polling_interval %d and max_retries %d",
+				providerConfig.APIPollingInterval, providerConfig.RetryPolicy.MaxRetries)
+		}
+		if token := request(t, providerConfig); token != "Bearer eu-token" {
+			t.Errorf("expected the token of the profile, got %q", token)
+		}
+	})
+
+	t.Run("attributes override the profile", func(t *testing.T) {
+		t.Setenv("SOLACECLOUD_PROFILE", "eu")
+		providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+			"api_token":   tftypes.NewValue(tftypes.String, "explicit-token"),
+			"max_retries": tftypes.NewValue(tftypes.Number, 1),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if providerConfig.APIPollingInterval != 10 || providerConfig.RetryPolicy.MaxRetries != 1 {
+			t.Errorf("expected max_retries to be overridden, got api_polling_interval %d and max_retries %d",
+				providerConfig.APIPollingInterval, providerConfig.RetryPolicy.MaxRetries)
+		}
+		if token := request(t, providerConfig); token != "Bearer explicit-token" {
+			t.Errorf("expected the token of the configuration, got %q", token)
+		}
+	})
+
+	t.Run("home cloud overrides the base URL of the profile", func(t *testing.T) {
+		t.Setenv("SOLACECLOUD_PROFILE", "eu")
+		providerConfig, resp := configureProvider(t, map[string]tftypes.Value{
+			"home_cloud": tftypes.NewValue(tftypes.String, "eu"),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if server := providerConfig.APIClient.ClientInterface.(*missioncontrol.Client).Server; server != "https://api.solacecloud.eu/" {
+			t.Errorf("expected the base URL of the home cloud, got %s", server)
+		}
+	})
+
+	t.Run("environment variables override the profile", func(t *testing.T) {
+		t.Setenv("SOLACECLOUD_PROFILE", "eu")
+		t.Setenv("SOLACECLOUD_API_TOKEN", "env-token")
+		t.Setenv("SOLACECLOUD_MAX_RETRIES", "3")
+		providerConfig, resp := configureProvider(t, map[string]tftypes.Value{})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if providerConfig.APIPollingInterval != 10 || providerConfig.RetryPolicy.MaxRetries != 3 {
+			t.Errorf("expected max_retries from the environment, got api_polling_interval %d and max_retries %d",
+				providerConfig.APIPollingInterval, providerConfig.RetryPolicy.MaxRetries)
+		}
+		if token := request(t, providerConfig); token != "Bearer env-token" {
+			t.Errorf("expected the token of the environment, got %q", token)
+		}
+	})
+
+	for _, tc := range []struct {
+		name    string
+		profile string
+		message string
+	}{
+		{name: "unknown setting", profile: "us", message: `unknown setting "unknown_setting"`},
+		{name: "missing profile", profile: "au", message: `profile "au" not found`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, resp := configureProvider(t, map[string]tftypes.Value{"profile": tftypes.NewValue(tftypes.String, tc.profile)})
+			if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tc.message) {
+				t.Errorf("expected an error containing %s, got %v", tc.message, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+var oauth2ClientCredentialsType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"token_url":     tftypes.String,
+	"client_id":     tftypes.String,
+	"client_secret": tftypes.String,
+	"scopes":        tftypes.List{ElementType: tftypes.String},
+}}
+
+// testCACertPEM is a self-signed certificate, only parsed by the tests.
+const testCACertPEM = `-----BEGIN CERTIFICATE-----
+MIIBXjCCAQWgAwIBAgIBATAKBggqhkjOPQQDAjAXMRUwEwYDVQQDEwxUZXN0IFJv
+b3QgQ0EwHhcNMjUwMTAxMDAwMDAwWhcNMzUwMTAxMDAwMDAwWjAXMRUwEwYDVQQD
+EwxUZXN0IFJvb3QgQ0EwWTATBgcqhkjOPQIBBggqhkjOPQMBBwNCAAS7ZCdynctj
+WIPreVHggi7aYr61DaGZIjK3PIhfuVl5bGDZ/BeVjgE5/HJkyrTRSt20Qulrh7Ur
+XNEW78FUrS9Co0IwQDAOBgNVHQ8BAf8EBAMCAgQwDwYDVR0TAQH/BAUwAwEB/zAd
+BgNVHQ4EFgQUhaLO18/LrT26ra/ZlqUgBzNn6YowCgYIKoZIzj0EAwIDRwAwRAIf
+dFqgez8JRP3Do4+TJzy39tAtHjMVhwurzWd2Pk9grwIhAMmjwmaoy61BZdjaX7Zs
+dJ68tdWYUsrwSQ6vDUAKC6q9
+-----END CERTIFICATE-----
+`
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || (len(substr) > 0 && containsSubstring(s, substr)))
@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-solacecloud/internal/model"
+	"terraform-provider-solacecloud/internal/shared"
+	"terraform-provider-solacecloud/internal/util"
+	"terraform-provider-solacecloud/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &ServiceCredentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &ServiceCredentialsEphemeralResource{}
+)
+
+// NewServiceCredentialsEphemeralResource is a helper function to simplify the provider implementation.
+func NewServiceCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &ServiceCredentialsEphemeralResource{}
+}
+
+// ServiceCredentialsEphemeralResource fetches the credentials of a service when they are needed, for example to
+// configure the solacebroker provider, without ever writing them to the plan or the state.
+type ServiceCredentialsEphemeralResource struct {
+	APIClient   *RetryableClientWithResponses
+	Permissions shared.Permissions
+}
+
+// ServiceCredentialsEphemeralResourceModel maps the ephemeral resource schema data.
+type ServiceCredentialsEphemeralResourceModel struct {
+	ServiceId                   types.String `tfsdk:"service_id"`
+	MessageVpnName              types.String `tfsdk:"message_vpn_name"`
+	ManagerManagementCredential types.Object `tfsdk:"manager_management_credential"`
+	EditorManagementCredential  types.Object `tfsdk:"editor_management_credential"`
+	ViewerManagementCredential  types.Object `tfsdk:"viewer_management_credential"`
+	MessagingClientCredential   types.Object `tfsdk:"messaging_client_credential"`
+	DmrClusterPassword          types.String `tfsdk:"dmr_cluster_password"`
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *ServiceCredentialsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(shared.ProviderConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected shared.ProviderConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.APIClient = NewRetryableClient(providerConfig.APIClient, providerConfig.RetryPolicy)
+	e.Permissions = providerConfig.Permissions
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *ServiceCredentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_credentials"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *ServiceCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the management, messaging client and DMR cluster credentials of an event broker " +
+			"service. The credentials are never stored in the plan or the state, use it to configure the " +
+			"solacebroker provider or anything else that needs them during a Terraform run.",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the event broker service.",
+				Required:            true,
+			},
+			"message_vpn_name": schema.StringAttribute{
+				MarkdownDescription: "The Message VPN to return the credentials of. Defaults to the Message VPN of the service.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"manager_management_credential": credentialAttributeSchema("The credentials of the Mission Control manager " +
+				"management user, tied to the Manager role."),
+			"editor_management_credential": credentialAttributeSchema("The credentials of the management admin user, " +
+				"tied to the Editor role."),
+			"viewer_management_credential": credentialAttributeSchema("The credentials of the read-only management " +
+				"user, tied to the Viewer role."),
+			"messaging_client_credential": credentialAttributeSchema("The credentials messaging clients use to connect " +
+				"to the Message VPN."),
+			"dmr_cluster_password": schema.StringAttribute{
+				MarkdownDescription: "The password of the DMR cluster.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func credentialAttributeSchema(description string) schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Computed: true,
+			},
+			"password": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// Open fetches the credentials from Mission Control.
+func (e *ServiceCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx = shared.MaskSensitiveLogFields(ctx)
+	resp.Diagnostics.Append(e.Permissions.Check("solacecloud_service_credentials", shared.ReadServicesPermission)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data ServiceCredentialsEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(e.readCredentials(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (e *ServiceCredentialsEphemeralResource) readCredentials(ctx context.Context, data *ServiceCredentialsEphemeralResourceModel) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if e.APIClient == nil {
+		diagnostics.AddError(
+			"Unconfigured Mission Control API Client",
+			"Cannot fetch the service credentials, the provider has not been configured.",
+		)
+		return diagnostics
+	}
+
+	serviceId := data.ServiceId.ValueString()
+	expand := []missioncontrol.GetServiceParamsExpand{missioncontrol.GetServiceParamsExpandBroker}
+	apiClientResp, err := e.APIClient.GetServiceWithResponse(ctx, serviceId, &missioncontrol.GetServiceParams{Expand: &expand})
+	if err != nil {
+		diagnostics.AddError("Error Reading Service", fmt.Sprintf("Could not read service %s: %s", serviceId, err))
+		return diagnostics
+	}
+
+	errorHandler := shared.NewMissionControlErrorResponseAdaptor(
+		http.StatusOK,
+		apiClientResp.Body,
+		apiClientResp.HTTPResponse,
+		nil,
+		apiClientResp.JSON401,
+		apiClientResp.JSON403,
+		apiClientResp.JSON404,
+		apiClientResp.JSON503,
+	)
+	if errorHandler.HandleError(&diagnostics) {
+		return diagnostics
+	}
+
+	if apiClientResp.JSON200 == nil || apiClientResp.JSON200.Data.Broker == nil ||
+		apiClientResp.JSON200.Data.Broker.MsgVpns == nil || len(*apiClientResp.JSON200.Data.Broker.MsgVpns) == 0 {
+		diagnostics.AddError(
+			"Service Credentials Not Available",
+			fmt.Sprintf("The response for service %s did not include its Message VPN, the service may still be "+
+				"provisioning.", serviceId),
+		)
+		return diagnostics
+	}
+	service := apiClientResp.JSON200.Data
+	broker := service.Broker
+	msgVpns := *broker.MsgVpns
+
+	index := 0
+	if util.IsKnown(data.MessageVpnName) {
+		var found bool
+		index, found = findMessageVpn(msgVpns, data.MessageVpnName.ValueString())
+		if !found {
+			diagnostics.AddAttributeError(
+				path.Root("message_vpn_name"),
+				"Message VPN Not Found",
+				fmt.Sprintf("Service %s has no Message VPN named %q.", serviceId, data.MessageVpnName.ValueString()),
+			)
+			return diagnostics
+		}
+	} else if service.MsgVpnName != nil {
+		index, _ = findMessageVpn(msgVpns, *service.MsgVpnName)
+	}
+	msgVpn := msgVpns[index]
+	data.MessageVpnName = types.StringPointerValue(msgVpn.MsgVpnName)
+	tflog.Debug(ctx, fmt.Sprintf("Fetched the credentials of Message VPN %s of service %s", data.MessageVpnName.ValueString(), serviceId))
+
+	var diags diag.Diagnostics
+	data.ManagerManagementCredential, diags = managementCredentialValue(msgVpn.MissionControlManagerLoginCredential)
+	diagnostics.Append(diags...)
+	data.EditorManagementCredential, diags = managementCredentialValue(msgVpn.ManagementAdminLoginCredential)
+	diagnostics.Append(diags...)
+	data.ViewerManagementCredential, diags = managementCredentialValue(broker.ManagementReadOnlyLoginCredential)
+	diagnostics.Append(diags...)
+
+	data.MessagingClientCredential = types.ObjectNull(model.BasicAuthCredentialObjectType().AttrTypes)
+	if msgVpn.ServiceLoginCredential != nil {
+		data.MessagingClientCredential, diags = model.BasicAuthCredentialModel{
+			Username: types.StringPointerValue(msgVpn.ServiceLoginCredential.Username),
+			Password: types.StringPointerValue(msgVpn.ServiceLoginCredential.Password),
+		}.ToObjectValue()
+		diagnostics.Append(diags...)
+	}
+
+	data.DmrClusterPassword = types.StringNull()
+	if broker.Cluster != nil {
+		data.DmrClusterPassword = types.StringPointerValue(broker.Cluster.Password)
+	}
+
+	return diagnostics
+}
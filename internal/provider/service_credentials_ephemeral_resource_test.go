@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"terraform-provider-solacecloud/internal/model"
+	"terraform-provider-solacecloud/internal/shared"
+	mc "terraform-provider-solacecloud/missioncontrol"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func credentialTestService() mc.Service {
+	primary := testMsgVpn("primary")
+	primary.MissionControlManagerLoginCredential = &mc.ManagementLoginCredential{Username: ptr("manager"), Password: ptr("manager-secret")}
+	primary.ManagementAdminLoginCredential = &mc.ManagementLoginCredential{Username: ptr("editor"), Password: ptr("editor-secret")}
+	other := testMsgVpn("other")
+
+	service := testService(other, primary)
+	service.MsgVpnName = ptr("primary")
+	service.Broker.ManagementReadOnlyLoginCredential = &mc.ManagementLoginCredential{Username: ptr("viewer"), Password: ptr("viewer-secret")}
+	service.Broker.Cluster.Password = ptr("dmr-secret")
+	return *service
+}
+
+func openServiceCredentials(t *testing.T, client CRUDClientWithResponses, messageVpnName types.String) (ServiceCredentialsEphemeralResourceModel, *ephemeral.OpenResponse) {
+	t.Helper()
+	ctx := context.Background()
+	e := &ServiceCredentialsEphemeralResource{APIClient: NewRetryableClient(client, shared.RetryPolicy{})}
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	e.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := config.Set(ctx, &ServiceCredentialsEphemeralResourceModel{
+		ServiceId:                   types.StringValue("service-id"),
+		MessageVpnName:              messageVpnName,
+		ManagerManagementCredential: types.ObjectNull(model.BasicAuthCredentialObjectType().AttrTypes),
+		EditorManagementCredential:  types.ObjectNull(model.BasicAuthCredentialObjectType().AttrTypes),
+		ViewerManagementCredential:  types.ObjectNull(model.BasicAuthCredentialObjectType().AttrTypes),
+		MessagingClientCredential:   types.ObjectNull(model.BasicAuthCredentialObjectType().AttrTypes),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: config.Raw}}
+	e.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+
+	var result ServiceCredentialsEphemeralResourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.Result.Get(ctx, &result)...)
+	}
+	return result, resp
+}
+
+func credentialOf(t *testing.T, value types.Object) model.BasicAuthCredentialModel {
+	t.Helper()
+	var credential model.BasicAuthCredentialModel
+	if diags := value.As(context.Background(), &credential, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return credential
+}
+
+func TestServiceCredentialsOpen(t *testing.T) {
+	t.Run("message vpn of the service", func(t *testing.T) {
+		client := &stubServiceClient{getStatus: http.StatusOK, service: credentialTestService()}
+		result, resp := openServiceCredentials(t, client, types.StringNull())
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if result.MessageVpnName.ValueString() != "primary" {
+			t.Errorf("expected message_vpn_name primary, got %s", result.MessageVpnName)
+		}
+		for name, tc := range map[string]struct {
+			value    types.Object
+			username string
+			password string
+		}{
+			"manager":          {result.ManagerManagementCredential, "manager", "manager-secret"},
+			"editor":           {result.EditorManagementCredential, "editor", "editor-secret"},
+			"viewer":           {result.ViewerManagementCredential, "viewer", "viewer-secret"},
+			"messaging client": {result.MessagingClientCredential, "primary-user", "secret"},
+		} {
+			credential := credentialOf(t, tc.value)
+			if credential.Username.ValueString() != tc.username || credential.Password.ValueString() != tc.password {
+				t.Errorf("%s: expected %s/%s, got %s/%s", name, tc.username, tc.password, credential.Username, credential.Password)
+			}
+		}
+		if result.DmrClusterPassword.ValueString() != "dmr-secret" {
+			t.Errorf("expected dmr_cluster_password dmr-secret, got %s", result.DmrClusterPassword)
+		}
+	})
+
+	t.Run("requested message vpn", func(t *testing.T) {
+		client := &stubServiceClient{getStatus: http.StatusOK, service: credentialTestService()}
+		result, resp := openServiceCredentials(t, client, types.StringValue("other"))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if credentialOf(t, result.MessagingClientCredential).Username.ValueString() != "other-user" {
+			t.Errorf("expected the credentials of message vpn other, got %v", result.MessagingClientCredential)
+		}
+		if !result.ManagerManagementCredential.IsNull() {
+			t.Errorf("expected no manager credential, got %v", result.ManagerManagementCredential)
+		}
+	})
+
+	t.Run("unknown message vpn", func(t *testing.T) {
+		client := &stubServiceClient{getStatus: http.StatusOK, service: credentialTestService()}
+		_, resp := openServiceCredentials(t, client, types.StringValue("missing"))
+		if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Message VPN Not Found" {
+			t.Errorf("expected a Message VPN Not Found error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("service still provisioning", func(t *testing.T) {
+		client := &stubServiceClient{getStatus: http.StatusOK, service: mc.Service{Name: ptr("my-service")}}
+		_, resp := openServiceCredentials(t, client, types.StringNull())
+		if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Service Credentials Not Available" {
+			t.Errorf("expected a Service Credentials Not Available error, got %v", resp.Diagnostics)
+		}
+	})
+}
+
+func TestMapServiceResponseWithoutCredentials(t *testing.T) {
+	ctx := context.Background()
+	service := credentialTestService()
+
+	data := ServiceResourceModel{Id: types.StringValue("id"), StoreCredentials: types.BoolValue(false)}
+	if diags := mapServiceResponse(ctx, &service, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var msgVpn model.MessageVpnModel
+	if diags := data.MessageVpn.As(ctx, &msgVpn, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	for name, value := range map[string]types.Object{
+		"manager":          msgVpn.ManagerManagementCredential,
+		"editor":           msgVpn.EditorManagementCredential,
+		"viewer":           msgVpn.ViewerManagementCredential,
+		"messaging client": msgVpn.MessagingClientCredential,
+	} {
+		credential := credentialOf(t, value)
+		if credential.Username.IsNull() || !credential.Password.IsNull() {
+			t.Errorf("%s: expected a username without password, got %s/%s", name, credential.Username, credential.Password)
+		}
+	}
+
+	var cluster model.DmrClusterInfoModel
+	if diags := data.DmrClusterInfo.As(ctx, &cluster, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !cluster.Password.IsNull() {
+		t.Errorf("expected no dmr cluster password, got %s", cluster.Password)
+	}
+
+	// The response itself is left untouched.
+	if *service.Broker.Cluster.Password != "dmr-secret" || *(*service.Broker.MsgVpns)[1].ServiceLoginCredential.Password != "secret" {
+		t.Errorf("expected the service response to keep its passwords")
+	}
+}
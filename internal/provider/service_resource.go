@@ -8,18 +8,15 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
-	"terraform-provider-solacecloud/internal/model"
 	"terraform-provider-solacecloud/internal/shared"
 	"terraform-provider-solacecloud/internal/util"
 	"terraform-provider-solacecloud/missioncontrol"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -33,11 +30,22 @@ func (r *ServiceResource) Configure(_ context.Context, req resource.ConfigureReq
 	providerConfig := req.ProviderData.(shared.ProviderConfig)
 
 	//r.APIClient = providerConfig.APIClient
-	r.APIClient = NewRetryableClient(providerConfig.APIClient, 3, 10)
+	r.APIClient = NewRetryableClient(providerConfig.APIClient, providerConfig.RetryPolicy)
 	r.APIPollingInterval = providerConfig.APIPollingInterval
+	r.DefaultDatacenterId = providerConfig.DefaultDatacenterId
+	r.DefaultEnvironment = providerConfig.DefaultEnvironment
+	r.DefaultServiceClassId = providerConfig.DefaultServiceClassId
+	if providerConfig.PlatformClient != nil {
+		r.PlatformClient = shared.NewRetryablePlatformClient(providerConfig.PlatformClient, providerConfig.RetryPolicy)
+	}
+	r.OrganizationId = providerConfig.OrganizationId
+	r.Permissions = providerConfig.Permissions
+	r.ReplaceOnImmutableChange = providerConfig.ReplaceOnImmutableChange
+	r.ServiceClasses = providerConfig.ServiceClasses
 }
 
 func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = shared.MaskSensitiveLogFields(ctx)
 	var data ServiceResourceModel
 
 	// Read Terraform plan data into the model
@@ -47,9 +55,123 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	maxAttempts := 1
+	if data.OnCreateFailure.ValueString() == onCreateFailureDelete {
+		maxAttempts += int(data.CreateRetries.ValueInt64())
+	}
+
+	for attempt := 1; ; attempt++ {
+		///////////////////////////////////////////////
+		// Send SCService Create Request
+		///////////////////////////////////////////////
+		serviceResourceID, adopted, diags := r.requestServiceCreation(ctx, &data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Save SC Resource Values into the Terraform state.
+		data.Id = types.StringValue(serviceResourceID)
+
+		tflog.Info(ctx, fmt.Sprintf("Service Resource ID: %s", serviceResourceID))
+
+		// Save the ID straight away: from now on the service exists in Solace Cloud, so if anything below fails Terraform
+		// keeps track of it and marks it as tainted, instead of forgetting about a service we are still being billed for.
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), serviceResourceID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		//////////////////////////////////////////////////////
+		// Wait & Check if SCService is still being created
+		//////////////////////////////////////////////////////
+
+		creationFailed, waitDiags := r.waitForServiceCreation(ctx, serviceResourceID)
+		if !waitDiags.HasError() {
+			resp.Diagnostics.Append(waitDiags...)
+			break
+		}
+		if !creationFailed || data.OnCreateFailure.ValueString() != onCreateFailureDelete {
+			resp.Diagnostics.Append(waitDiags...)
+			addServiceTaintedError(&resp.Diagnostics, serviceResourceID)
+			return
+		}
+		// This apply did not create an adopted service, so it is not ours to delete.
+		if adopted {
+			resp.Diagnostics.Append(waitDiags...)
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				"Adopted Service Not Deleted",
+				fmt.Sprintf("Service %s was adopted with adopt_existing rather than created by this apply, so on_create_failure "+
+					"does not delete it. It has been saved to the Terraform state and marked as tainted: delete it from the "+
+					"Solace Cloud Console, or let the next apply replace it.", serviceResourceID),
+			)
+			return
+		}
+
+		deleteDiags := r.deleteFailedService(ctx, serviceResourceID)
+		if deleteDiags.HasError() {
+			resp.Diagnostics.Append(waitDiags...)
+			resp.Diagnostics.Append(deleteDiags...)
+			addServiceTaintedError(&resp.Diagnostics, serviceResourceID)
+			return
+		}
+		resp.State.RemoveResource(ctx)
+
+		if attempt >= maxAttempts {
+			resp.Diagnostics.Append(waitDiags...)
+			resp.Diagnostics.AddError(
+				"Failed Service Deleted",
+				fmt.Sprintf("Service %s was deleted because its creation failed, after %d attempt(s).", serviceResourceID, attempt),
+			)
+			return
+		}
+
+		tflog.Warn(ctx, fmt.Sprintf("Creation of service %s failed and the service was deleted, retrying (attempt %d of %d)",
+			serviceResourceID, attempt+1, maxAttempts))
+		data.Id = types.StringUnknown()
+	}
+	serviceResourceID := data.Id.ValueString()
+
 	///////////////////////////////////////////////
-	// Send SCService Create Request
+	// After SCService creation has been COMPLETED
+	// Get Connection Properties from the Service
 	///////////////////////////////////////////////
+
+	resp.Diagnostics.Append(*r.readDataInternal(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		addServiceTaintedError(&resp.Diagnostics, serviceResourceID)
+		return
+	}
+
+	var plan ServiceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateDiags := r.updateInternal(ctx, &data, &plan)
+	resp.Diagnostics.Append(updateDiags...)
+	if resp.Diagnostics.HasError() {
+		addServiceTaintedError(&resp.Diagnostics, serviceResourceID)
+		return
+	}
+
+	// Read the updated service data after potential updates
+	resp.Diagnostics.Append(*r.readDataInternal(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		addServiceTaintedError(&resp.Diagnostics, serviceResourceID)
+		return
+	}
+
+	// update state with data
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// requestServiceCreation sends the CreateService request and returns the ID of the new service, or of the existing
+// service that is adopted instead when adopt_existing is set, reporting whether it was adopted.
+func (r *ServiceResource) requestServiceCreation(ctx context.Context, data *ServiceResourceModel) (string, bool, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
 	varServiceBody := missioncontrol.CreateServiceRequest{
 		Name:         data.Name.ValueString(),
 		DatacenterId: data.DatacenterId.ValueString(),
@@ -85,40 +207,49 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 
 	apiClientCreateResp, err := r.APIClient.CreateServiceWithResponse(ctx, varServiceBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diagnostics.AddError(
 			"Error calling Solace Cloud API",
 			"Could not create/get service, unexpected error: "+err.Error(),
 		)
-		return
+		return "", false, diagnostics
 	}
 
-	errorHandler := shared.NewMissionControlErrorResponseAdaptor(
-		http.StatusAccepted,
-		apiClientCreateResp.Body,
-		apiClientCreateResp.HTTPResponse,
-		apiClientCreateResp.JSON400,
-		apiClientCreateResp.JSON401,
-		apiClientCreateResp.JSON403,
-		nil, // JSON404 not available for CreateServiceResponse
-		apiClientCreateResp.JSON503,
-	)
-	if errorHandler.HandleError(&resp.Diagnostics) {
-		return
-	}
-
-	tflog.Trace(ctx, fmt.Sprintf("Service CREATED Http Response body: %s", apiClientCreateResp.Body))
+	var serviceResourceID string
+	adopted := apiClientCreateResp.StatusCode() == http.StatusConflict && data.AdoptExisting.ValueBool()
+	if adopted {
+		var adoptDiags diag.Diagnostics
+		serviceResourceID, adoptDiags = r.findServiceToAdopt(ctx, data)
+		diagnostics.Append(adoptDiags...)
+		if diagnostics.HasError() {
+			return "", false, diagnostics
+		}
+	} else {
+		errorHandler := shared.NewMissionControlErrorResponseAdaptor(
+			http.StatusAccepted,
+			apiClientCreateResp.Body,
+			apiClientCreateResp.HTTPResponse,
+			apiClientCreateResp.JSON400,
+			apiClientCreateResp.JSON401,
+			apiClientCreateResp.JSON403,
+			nil, // JSON404 not available for CreateServiceResponse
+			apiClientCreateResp.JSON503,
+		)
+		if errorHandler.HandleError(&diagnostics) {
+			return "", false, diagnostics
+		}
 
-	var resData = apiClientCreateResp.JSON202.Data
-	var serviceResourceID = *resData.ResourceId
+		tflog.Trace(ctx, fmt.Sprintf("Service CREATED Http Response body: %s", shared.RedactBody(apiClientCreateResp.Body)))
 
-	// Save SC Resource Values into the Terraform state.
-	data.Id = types.StringValue(serviceResourceID)
+		serviceResourceID = *apiClientCreateResp.JSON202.Data.ResourceId
+	}
 
-	tflog.Info(ctx, fmt.Sprintf("Service Resource ID: %s", serviceResourceID))
+	return serviceResourceID, adopted, diagnostics
+}
 
-	//////////////////////////////////////////////////////
-	// Wait & Check if SCService is still being created
-	//////////////////////////////////////////////////////
+// waitForServiceCreation polls the service until its creation completes, fails, or the operation is interrupted. It
+// reports whether the error is due to the service's creation having FAILED, as opposed to not knowing how it went.
+func (r *ServiceResource) waitForServiceCreation(ctx context.Context, serviceResourceID string) (bool, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
 
 	//Send Empty Params, we only need basic Info
 	createServParam := missioncontrol.GetServiceParams{}
@@ -126,75 +257,82 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 	for {
 		apiClientStatusResp, err := r.APIClient.GetServiceWithResponse(ctx, serviceResourceID, &createServParam)
 		if err != nil {
-			return
+			diagnostics.AddError(
+				"Error calling Solace Cloud API",
+				fmt.Sprintf("Could not get service %s while waiting for service creation to complete: %s", serviceResourceID, err),
+			)
+			return false, diagnostics
 		}
 
-		if apiClientStatusResp.StatusCode() != http.StatusOK {
+		if apiClientStatusResp.StatusCode() != http.StatusOK || apiClientStatusResp.JSON200 == nil {
 			if apiClientStatusResp.StatusCode() == http.StatusUnauthorized {
-				resp.Diagnostics.AddError(
+				diagnostics.AddError(
 					"Authentication Failed During Service Status Check",
 					"Received HTTP 401 Unauthorized while checking service creation status. "+
 						"This may indicate that your API token has expired or been revoked during the service creation process. "+
 						"Verify your authentication configuration and try again.",
 				)
-				return
+				return false, diagnostics
 			}
-			resp.Diagnostics.AddError(
+			diagnostics.AddError(
 				"Failed to get service while waiting for service creation to complete.",
 				fmt.Sprintf("Expected HTTP 200 but received %d while waiting for service to complete", apiClientStatusResp.StatusCode()),
 			)
-			return
+			return false, diagnostics
 		}
 
-		var SCServiceStatus = *apiClientStatusResp.JSON200.Data.CreationState
-
-		tflog.Trace(ctx, fmt.Sprintf("Service STATUS Http Response body: %s", apiClientStatusResp.Body))
+		tflog.Trace(ctx, fmt.Sprintf("Service STATUS Http Response body: %s", shared.RedactBody(apiClientStatusResp.Body)))
+
+		var SCServiceStatus = apiClientStatusResp.JSON200.Data.CreationState
+		if SCServiceStatus == nil {
+			tflog.Info(ctx, "Service creationState not reported yet, waiting")
+		} else {
+			if *SCServiceStatus == missioncontrol.ServiceCreationStateFAILED {
+				service := apiClientStatusResp.JSON200.Data
+				diagnostics.AddError(
+					"Resource Creation FAILED",
+					fmt.Sprintf("Received creationState as: %s from the GetService API Request.%s",
+						*SCServiceStatus, serviceErrorDetails(service.ErrorId, service.ErrorMessage)),
+				)
+				return true, diagnostics
+			}
+			if *SCServiceStatus == missioncontrol.ServiceCreationStateCOMPLETED {
+				tflog.Info(ctx, fmt.Sprintf("Service Status reported as %s, finished Waiting", missioncontrol.ServiceCreationStateCOMPLETED))
+				return false, diagnostics
+			}
+			tflog.Info(ctx, fmt.Sprintf("Waiting for Service Status: %s to Complete", *SCServiceStatus))
+		}
 
-		if SCServiceStatus == missioncontrol.ServiceCreationStateFAILED {
-			resp.Diagnostics.AddError(
-				"Resource Creation FAILED",
-				fmt.Sprintf("Received creationState as: %s from the GetService API Request", SCServiceStatus),
+		select {
+		case <-ctx.Done():
+			diagnostics.AddError(
+				"Service Creation Interrupted",
+				fmt.Sprintf("Stopped waiting for service %s to be created: %s", serviceResourceID, ctx.Err()),
 			)
-			return
+			return false, diagnostics
+		case <-time.After(time.Duration(r.APIPollingInterval) * time.Second):
 		}
-		if SCServiceStatus == missioncontrol.ServiceCreationStateCOMPLETED {
-			tflog.Info(ctx, fmt.Sprintf("Service Status reported as %s, finished Waiting", missioncontrol.ServiceCreationStateCOMPLETED))
-			break
-		}
-
-		tflog.Info(ctx, fmt.Sprintf("Waiting for Service Status: %s to Complete", SCServiceStatus))
-		time.Sleep(time.Duration(r.APIPollingInterval) * time.Second)
 	}
+}
 
-	///////////////////////////////////////////////
-	// After SCService creation has been COMPLETED
-	// Get Connection Properties from the Service
-	///////////////////////////////////////////////
-
-	r.readDataInternal(ctx, &data)
-
-	var plan ServiceResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	updateDiags := r.updateInternal(ctx, &data, &plan)
-	resp.Diagnostics.Append(updateDiags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	// Read the updated service data after potential updates
-	r.readDataInternal(ctx, &data)
-
-	// update state with data
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// addServiceTaintedError explains what happens to a service whose creation did not finish. Create saves the service ID
+// before waiting, so Terraform keeps the service in its state and marks it as tainted rather than orphaning it.
+func addServiceTaintedError(diagnostics *diag.Diagnostics, serviceResourceID string) {
+	diagnostics.AddAttributeError(
+		path.Root("id"),
+		"Service Creation Incomplete",
+		fmt.Sprintf("Service %s was created in Solace Cloud but could not be fully provisioned. It has been saved to the "+
+			"Terraform state and marked as tainted, so the next apply deletes and recreates it. If the service finishes "+
+			"creating on its own and you want to keep it, run `terraform untaint` on this resource before the next apply.",
+			serviceResourceID),
+	)
 }
 
 func (r *ServiceResource) readDataInternal(ctx context.Context, data *ServiceResourceModel) *diag.Diagnostics {
 	serviceResourceID := data.Id.ValueString()
 	varExpand := []missioncontrol.GetServiceParamsExpand{missioncontrol.GetServiceParamsExpandBroker}
 	varExpand = append(varExpand, missioncontrol.GetServiceParamsExpandServiceConnectionEndpoints)
+	varExpand = append(varExpand, missioncontrol.GetServiceParamsExpandAllowedActions)
 	varExpand = append(varExpand, missioncontrol.GetServiceParamsExpandMessageSpoolDetails)
 	getCredServParam := missioncontrol.GetServiceParams{Expand: &varExpand}
 	diagnostics := diag.Diagnostics{}
@@ -220,189 +358,29 @@ func (r *ServiceResource) readDataInternal(ctx context.Context, data *ServiceRes
 		return &diagnostics
 	}
 
-	tflog.Trace(ctx, fmt.Sprintf("SC Service Broker Details Http Response body: %s", apiClientGetCredResp.Body))
-
-	var diags diag.Diagnostics
-	var respData = apiClientGetCredResp.JSON200.Data
-	tflog.Info(ctx, fmt.Sprintf("Response Data: %+v", respData))
-
-	var respBroker = respData.Broker
+	tflog.Trace(ctx, fmt.Sprintf("SC Service Broker Details Http Response body: %s", shared.RedactBody(apiClientGetCredResp.Body)))
 
-	var respMsgVPN = (*respBroker.MsgVpns)[0]
-
-	// Check if MissionControlManagerLoginCredential is available
-	var managerManagementCredential basetypes.ObjectValue
-	if respMsgVPN.MissionControlManagerLoginCredential == nil {
-		tflog.Warn(ctx, "MissionControlManagerLoginCredential is not supported in this broker version. Setting null credentials.")
-		managerManagementCredential = types.ObjectNull(model.BasicAuthCredentialObjectType().AttrTypes)
-	} else {
-		var diags diag.Diagnostics
-		managerManagementCredential, diags = model.BasicAuthCredentialModel{
-			Username: types.StringValue(*respMsgVPN.MissionControlManagerLoginCredential.Username),
-			Password: types.StringValue(*respMsgVPN.MissionControlManagerLoginCredential.Password),
-		}.ToObjectValue()
-		diagnostics.Append(diags...)
-		if diagnostics.HasError() {
-			return &diagnostics
-		}
-	}
-
-	editorManagementCredential, diags := model.BasicAuthCredentialModel{
-		Username: types.StringValue(*respMsgVPN.ManagementAdminLoginCredential.Username),
-		Password: types.StringValue(*respMsgVPN.ManagementAdminLoginCredential.Password),
-	}.ToObjectValue()
-	diagnostics.Append(diags...)
-	if diagnostics.HasError() {
-		return &diagnostics
+	var service *missioncontrol.Service
+	if apiClientGetCredResp.JSON200 != nil {
+		service = &apiClientGetCredResp.JSON200.Data
 	}
-
-	viewerManagementCredential, diags := model.BasicAuthCredentialModel{
-		Username: types.StringValue(*respData.Broker.ManagementReadOnlyLoginCredential.Username),
-		Password: types.StringValue(*respData.Broker.ManagementReadOnlyLoginCredential.Password),
-	}.ToObjectValue()
-	diagnostics.Append(diags...)
+	diagnostics.Append(mapServiceResponse(ctx, service, data)...)
 	if diagnostics.HasError() {
 		return &diagnostics
 	}
 
-	messagingClientCredential, diags := model.BasicAuthCredentialModel{
-		Username: types.StringValue(*respMsgVPN.ServiceLoginCredential.Username),
-		Password: types.StringValue(*respMsgVPN.ServiceLoginCredential.Password),
-	}.ToObjectValue()
-	diagnostics.Append(diags...)
-	if diagnostics.HasError() {
-		return &diagnostics
-	}
-
-	supportedDmrAuthenticationModes, diags := types.ListValueFrom(ctx, types.StringType, respBroker.Cluster.SupportedAuthenticationMode)
-	diagnostics.Append(diags...)
-	if diagnostics.HasError() {
-		return &diagnostics
-	}
-
-	dmrClusterInfo, diags := model.DmrClusterInfoModel{
-		Name:                         types.StringValue(*respBroker.Cluster.Name),
-		Password:                     types.StringValue(*respBroker.Cluster.Password),
-		RemoteAddress:                types.StringValue(*respBroker.Cluster.RemoteAddress),
-		PrimaryRouterName:            types.StringValue(*respBroker.Cluster.PrimaryRouterName),
-		SupportedAuthenticationModes: supportedDmrAuthenticationModes,
-	}.ToObjectValue()
-	diagnostics.Append(diags...)
-	if diagnostics.HasError() {
-		return &diagnostics
-	}
-
-	messageVpn := model.MessageVpnModel{
-		Name:                            types.StringValue(*respMsgVPN.MsgVpnName),
-		AuthenticationBasicEnabled:      types.BoolValue(*respMsgVPN.AuthenticationBasicEnabled),
-		AuthenticationBasicType:         types.StringValue(string(*respMsgVPN.AuthenticationBasicType)),
-		AuthenticationClientCertEnabled: types.BoolValue(*respMsgVPN.AuthenticationClientCertEnabled),
-		AuthenticationClientCertValidateDateEnabled: types.BoolValue(*respMsgVPN.AuthenticationClientCertValidateDateEnabled),
-		MaxConnectionCount:                          types.Int64Value(int64(*respMsgVPN.MaxConnectionCount)),
-		MaxEgressFlowCount:                          types.Int64Value(int64(*respMsgVPN.MaxEgressFlowCount)),
-		MaxEndpointCount:                            types.Int64Value(int64(*respMsgVPN.MaxEndpointCount)),
-		MaxIngressFlowCount:                         types.Int64Value(int64(*respMsgVPN.MaxIngressFlowCount)),
-		MaxMsgSpoolUsage:                            types.Int64Value(int64(*respMsgVPN.MaxMsgSpoolUsage)),
-		MaxSubscriptionCount:                        types.Int64Value(int64(*respMsgVPN.MaxSubscriptionCount)),
-		MaxTransactedSessionCount:                   types.Int64Value(int64(*respMsgVPN.MaxTransactedSessionCount)),
-		MaxTransactionCount:                         types.Int64Value(int64(*respMsgVPN.MaxTransactionCount)),
-		TruststoreUri:                               types.StringPointerValue(respMsgVPN.TruststoreUri),
-		ManagerManagementCredential:                 managerManagementCredential,
-		EditorManagementCredential:                  editorManagementCredential,
-		ViewerManagementCredential:                  viewerManagementCredential,
-		MessagingClientCredential:                   messagingClientCredential,
-	}
-	data.MessageVpn, diags = messageVpn.ToObjectValue()
-	diagnostics.Append(diags...)
-	if diagnostics.HasError() {
-		return &diagnostics
-	}
-
-	data.DatacenterId = types.StringValue(*respData.DatacenterId)
-	data.ServiceClassId = types.StringValue(string(*respData.ServiceClassId))
-	data.Name = types.StringValue(*respData.Name)
-	data.EventBrokerVersion = types.StringValue(respData.EventBrokerServiceVersion)
-	data.MessageVpnName = types.StringPointerValue(respMsgVPN.MsgVpnName)
-	data.MaxSpoolUsage = types.Int64Value(int64(*respBroker.MaxSpoolUsage))
-	data.Locked = types.BoolPointerValue(respData.Locked)
-	data.EnvironmentId = types.StringPointerValue(respData.EnvironmentId)
-	data.MateLinkEncryption = types.BoolPointerValue(respBroker.RedundancyGroupSslEnabled)
-	data.ClusterName = types.StringPointerValue(respBroker.Cluster.Name)
-	data.OwnedBy = types.StringValue(*respData.OwnedBy)
-	data.DmrClusterInfo = dmrClusterInfo
-	name := determineCustomRouterName(respBroker.Cluster.PrimaryRouterName)
-	if name != "" {
-		data.CustomRouterName = types.StringValue(name)
-	}
-
-	connectionEndpointValuesList := make([]attr.Value, 0)
-	// TODO: Add Cluster object to the server resource Model and Fill out the Cluster Object based on the response
-	//       this allows the user to know what are the DMR Cluster details that got chosen by Solace Cloud.
-	for _, serviceConnectionEndpoint := range *respData.ServiceConnectionEndpoints {
-		portsObject, diags := model.ToObjectValue(serviceConnectionEndpoint.Ports)
-		diagnostics.Append(diags...)
-		if diagnostics.HasError() {
-			return &diagnostics
-		}
-
-		hostnameList, diags := types.ListValueFrom(ctx, types.StringType, serviceConnectionEndpoint.HostNames)
-		diagnostics.Append(diags...)
-		if diagnostics.HasError() {
-			return &diagnostics
-		}
-
-		connectionEndpointValue, diags := model.ConnectionEndpointModel{
-			Id:             types.StringPointerValue(serviceConnectionEndpoint.Id),
-			Name:           types.StringValue(serviceConnectionEndpoint.Name),
-			Description:    types.StringPointerValue(serviceConnectionEndpoint.Description),
-			AccessType:     types.StringValue(string(serviceConnectionEndpoint.AccessType)),
-			K8SServiceType: types.StringValue(string(*serviceConnectionEndpoint.K8sServiceType)),
-			K8SServiceId:   types.StringPointerValue(serviceConnectionEndpoint.K8sServiceId),
-			Hostnames:      hostnameList,
-			Ports:          portsObject,
-		}.ToObjectValue()
-
-		diagnostics.Append(diags...)
-		if diagnostics.HasError() {
-			return &diagnostics
-		}
-
-		connectionEndpointValuesList = append(connectionEndpointValuesList, connectionEndpointValue)
-	}
-
-	data.ConnectionEndpoints, diags = types.ListValue(
-		model.ConnectionEndpointSchema().Type(),
-		connectionEndpointValuesList)
-	diagnostics.Append(diags...)
-	if diagnostics.HasError() {
-		return &diags
-	}
-
-	// data.CustomRouterName: We should add this to the V2's response, otherwise we're only guessing what its effective
-	// value is, and there is no way to know for sure whether it has been overridden or not next time we do a
-	// Read operation. But for now we should be able to rely on the store terraform state, given this is not something
-	// that would change on us.
-
 	tflog.Info(ctx, fmt.Sprintf("ResourceId: %s - ResourceVPNName: %s - ResourceServiceClass: %s - ResourceDatacenterId: %s - ",
 		serviceResourceID,
-		*respMsgVPN.MsgVpnName,
-		*apiClientGetCredResp.JSON200.Data.ServiceClassId,
-		*apiClientGetCredResp.JSON200.Data.DatacenterId))
+		data.MessageVpnName.ValueString(),
+		data.ServiceClassId.ValueString(),
+		data.DatacenterId.ValueString()))
 	tflog.Trace(ctx, "##### Created Solace Cloud Resources #####")
 
-	return &diags
-
-}
-
-func determineCustomRouterName(primaryRouterName *string) string {
-	if strings.Contains(*primaryRouterName, "primarycn") {
-		return strings.Split(*primaryRouterName, "primarycn")[0]
-	}
-
-	return ""
+	return &diagnostics
 }
 
 func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = shared.MaskSensitiveLogFields(ctx)
 	var data ServiceResourceModel
 
 	// Read Terraform prior state data into the model
@@ -424,11 +402,20 @@ func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, re
 		}
 		return
 	}
+	resp.Diagnostics.Append(*diags...)
+	// Failed, destroyed and stopped services are kept in the state, ModifyPlan decides what to do with them.
+	if data.CreationState.ValueString() == string(missioncontrol.ServiceCreationStateFAILED) ||
+		data.AdminState.ValueString() == string(missioncontrol.DESTROY) ||
+		data.AdminState.ValueString() == string(missioncontrol.STOP) {
+		tflog.Warn(ctx, fmt.Sprintf("Service %s has creationState %s and adminState %s",
+			data.Id.ValueString(), data.CreationState.ValueString(), data.AdminState.ValueString()))
+	}
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = shared.MaskSensitiveLogFields(ctx)
 	var data ServiceResourceModel
 
 	// Read Terraform prior state data into the model
@@ -457,17 +444,18 @@ func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest
 		apiClientResp.JSON503,
 	)
 
+	// A service that is already gone, for example one that was being destroyed when it got replaced, is deleted.
+	if apiClientResp.StatusCode() == http.StatusNotFound {
+		tflog.Info(ctx, fmt.Sprintf("SC ResourceID ID: %s was already deleted", serviceId))
+		return
+	}
 	if errorHandler.HandleError(&resp.Diagnostics) {
 		return
 	}
 
-	tflog.Trace(ctx, fmt.Sprintf("SC Service DELETE Http Response body: %s", apiClientResp.Body))
+	tflog.Trace(ctx, fmt.Sprintf("SC Service DELETE Http Response body: %s", shared.RedactBody(apiClientResp.Body)))
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
-
-func (r *ServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-}
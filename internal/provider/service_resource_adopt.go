@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-solacecloud/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// findServiceToAdopt looks up the service that made CreateService fail with a 409 Conflict: a service with the same name
+// in the same environment. It is only adopted when its immutable attributes match the configuration, since adopting it
+// would otherwise silently manage a different service than the one that was configured.
+func (r *ServiceResource) findServiceToAdopt(ctx context.Context, data *ServiceResourceModel) (string, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	name := data.Name.ValueString()
+	environmentId := ""
+	if util.IsKnown(data.EnvironmentId) {
+		environmentId = data.EnvironmentId.ValueString()
+	}
+
+	candidates, diags := r.findServicesByName(ctx, name, environmentId)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return "", diagnostics
+	}
+
+	switch len(candidates) {
+	case 0:
+		diagnostics.AddAttributeError(
+			path.Root("adopt_existing"),
+			"No Service To Adopt",
+			fmt.Sprintf("Creating the service failed with a 409 Conflict, but no existing service named %q was found "+
+				"in the environment. The conflict is not caused by a service that can be adopted.", name),
+		)
+		return "", diagnostics
+	case 1:
+	default:
+		var ids []string
+		for _, candidate := range candidates {
+			ids = append(ids, *candidate.Id)
+		}
+		diagnostics.AddAttributeError(
+			path.Root("adopt_existing"),
+			"Multiple Services To Adopt",
+			fmt.Sprintf("Found %d services named %q: %s. Import the one you want to manage with `terraform import` instead.",
+				len(candidates), name, strings.Join(ids, ", ")),
+		)
+		return "", diagnostics
+	}
+
+	serviceId := *candidates[0].Id
+	existing, diags := r.readDataToStruct(ctx, types.StringValue(serviceId))
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return "", diagnostics
+	}
+
+	changes := immutableAttributeChanges(existing, *data)
+	if len(changes) > 0 {
+		var diff []string
+		for _, change := range changes {
+			diff = append(diff, fmt.Sprintf("  %s: existing %s, configured %s", change.path, change.stateValue, change.planValue))
+		}
+		diagnostics.AddAttributeError(
+			path.Root("adopt_existing"),
+			"Existing Service Does Not Match",
+			fmt.Sprintf("Service %s is named %q but cannot be adopted, as these attributes cannot be changed after "+
+				"creation and differ from the configuration:\n%s\n\nRename the service in the configuration, or update "+
+				"the configuration to match the existing service.", serviceId, name, strings.Join(diff, "\n")),
+		)
+		return "", diagnostics
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Adopting existing service %s named %s", serviceId, name))
+	return serviceId, diagnostics
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-solacecloud/internal/shared"
+	"terraform-provider-solacecloud/missioncontrol"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The on_create_failure policies, applied when a service's creationState becomes FAILED.
+const (
+	// onCreateFailureKeep keeps the failed service, tracked as tainted so that the next apply replaces it.
+	onCreateFailureKeep = "keep"
+	// onCreateFailureDelete deletes the failed service, then retries creating it up to create_retries times.
+	onCreateFailureDelete = "delete"
+)
+
+// serviceErrorDetails formats the error reported by Solace Cloud for a service, for use in a diagnostic detail.
+func serviceErrorDetails(errorId *string, errorMessage *string) string {
+	var details []string
+	if errorId != nil && *errorId != "" {
+		details = append(details, fmt.Sprintf("Error ID: %s.", *errorId))
+	}
+	if errorMessage != nil && *errorMessage != "" {
+		details = append(details, fmt.Sprintf("Error Message: %s", *errorMessage))
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return " " + strings.Join(details, " ")
+}
+
+// deleteFailedService deletes a service whose creation failed and waits until it is gone, so that the name is free to
+// be used again when creation is retried.
+func (r *ServiceResource) deleteFailedService(ctx context.Context, serviceResourceID string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting service %s as its creation failed", serviceResourceID))
+
+	apiClientResp, err := r.APIClient.DeleteServiceWithResponse(ctx, serviceResourceID)
+	if err != nil {
+		diagnostics.AddError(
+			"Error Deleting Failed Service",
+			fmt.Sprintf("Could not delete service %s after its creation failed: %s", serviceResourceID, err),
+		)
+		return diagnostics
+	}
+
+	errorHandler := shared.NewMissionControlErrorResponseAdaptor(
+		http.StatusAccepted,
+		apiClientResp.Body,
+		apiClientResp.HTTPResponse,
+		apiClientResp.JSON400,
+		apiClientResp.JSON401,
+		apiClientResp.JSON403,
+		apiClientResp.JSON404,
+		apiClientResp.JSON503,
+	)
+	if apiClientResp.StatusCode() != http.StatusNotFound && errorHandler.HandleError(&diagnostics) {
+		return diagnostics
+	}
+
+	return r.waitForServiceDeletion(ctx, serviceResourceID)
+}
+
+// waitForServiceDeletion polls the service until the API no longer finds it.
+func (r *ServiceResource) waitForServiceDeletion(ctx context.Context, serviceResourceID string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	for {
+		apiClientStatusResp, err := r.APIClient.GetServiceWithResponse(ctx, serviceResourceID, &missioncontrol.GetServiceParams{})
+		if err != nil {
+			diagnostics.AddError(
+				"Error calling Solace Cloud API",
+				fmt.Sprintf("Could not get service %s while waiting for it to be deleted: %s", serviceResourceID, err),
+			)
+			return diagnostics
+		}
+
+		switch apiClientStatusResp.StatusCode() {
+		case http.StatusNotFound:
+			tflog.Info(ctx, fmt.Sprintf("Service %s deleted", serviceResourceID))
+			return diagnostics
+		case http.StatusOK:
+			tflog.Info(ctx, fmt.Sprintf("Waiting for service %s to be deleted", serviceResourceID))
+		default:
+			diagnostics.AddError(
+				"Failed to get service while waiting for service deletion to complete.",
+				fmt.Sprintf("Expected HTTP 200 or 404 but received %d while waiting for service %s to be deleted",
+					apiClientStatusResp.StatusCode(), serviceResourceID),
+			)
+			return diagnostics
+		}
+
+		select {
+		case <-ctx.Done():
+			diagnostics.AddError(
+				"Service Deletion Interrupted",
+				fmt.Sprintf("Stopped waiting for service %s to be deleted: %s", serviceResourceID, ctx.Err()),
+			)
+			return diagnostics
+		case <-time.After(time.Duration(r.APIPollingInterval) * time.Second):
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"terraform-provider-solacecloud/internal/model"
+	"terraform-provider-solacecloud/internal/shared"
+	mc "terraform-provider-solacecloud/missioncontrol"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// stubServiceClient answers the calls made by Create, the remaining methods panic through the nil embedded interface.
+type stubServiceClient struct {
+	CRUDClientWithResponses
+	serviceId string
+	getStatus int
+	service   mc.Service
+	conflict  bool
+	services  []mc.ServiceSummary
+
+	// failedCreations makes the first created services FAILED, until they are deleted.
+	failedCreations int
+	creations       int
+	deleted         bool
+}
+
+func (c *stubServiceClient) CreateServiceWithResponse(_ context.Context, _ mc.CreateServiceJSONRequestBody, _ ...mc.RequestEditorFn) (*mc.CreateServiceResponse, error) {
+	if c.conflict {
+		return &mc.CreateServiceResponse{HTTPResponse: &http.Response{StatusCode: http.StatusConflict}}, nil
+	}
+	c.creations++
+	c.deleted = false
+	return &mc.CreateServiceResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusAccepted},
+		JSON202:      &mc.OperationResponse{Data: mc.Operation{ResourceId: &c.serviceId}},
+	}, nil
+}
+
+func (c *stubServiceClient) GetServiceWithResponse(_ context.Context, _ string, _ *mc.GetServiceParams, _ ...mc.RequestEditorFn) (*mc.GetServiceResponse, error) {
+	if c.getStatus != http.StatusOK {
+		return &mc.GetServiceResponse{HTTPResponse: &http.Response{StatusCode: c.getStatus}}, nil
+	}
+	if c.deleted {
+		return &mc.GetServiceResponse{HTTPResponse: &http.Response{StatusCode: http.StatusNotFound}}, nil
+	}
+	service := c.service
+	if c.failedCreations > 0 {
+		service.CreationState = ptr(mc.ServiceCreationStateCOMPLETED)
+		if c.creations <= c.failedCreations {
+			service.CreationState = ptr(mc.ServiceCreationStateFAILED)
+			service.ErrorId = ptr("error-id")
+			service.ErrorMessage = ptr("no capacity")
+		}
+	}
+	return &mc.GetServiceResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+		JSON200:      &mc.ServiceResponse{Data: service},
+	}, nil
+}
+
+func (c *stubServiceClient) UpdateServiceWithBodyWithResponse(_ context.Context, _ string, _ string, _ io.Reader, _ ...mc.RequestEditorFn) (*mc.UpdateServiceResponse, error) {
+	return &mc.UpdateServiceResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}, nil
+}
+
+func (c *stubServiceClient) DeleteServiceWithResponse(_ context.Context, _ string, _ ...mc.RequestEditorFn) (*mc.DeleteServiceResponse, error) {
+	c.deleted = true
+	return &mc.DeleteServiceResponse{HTTPResponse: &http.Response{StatusCode: http.StatusAccepted}}, nil
+}
+
+func (c *stubServiceClient) GetServicesWithResponse(_ context.Context, _ *mc.GetServicesParams, _ ...mc.RequestEditorFn) (*mc.GetServicesResponse, error) {
+	return &mc.GetServicesResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+		JSON200:      &mc.ServiceSummaryResponse{Data: c.services},
+	}, nil
+}
+
+func plannedService(t *testing.T, r *ServiceResource, adoptExisting bool) (tfsdk.Plan, tfsdk.State) {
+	return plannedServiceWithFailurePolicy(t, r, adoptExisting, types.StringNull(), types.Int64Null())
+}
+
+func plannedServiceWithFailurePolicy(t *testing.T, r *ServiceResource, adoptExisting bool, onCreateFailure types.String, createRetries types.Int64) (tfsdk.Plan, tfsdk.State) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := plan.Set(ctx, ServiceResourceModel{
+		Name:                types.StringValue("my-service"),
+		DatacenterId:        types.StringValue("aws-ca-central-1a"),
+		ServiceClassId:      types.StringValue("DEVELOPER"),
+		ConnectionEndpoints: types.ListNull(model.ConnectionEndpointSchema().Type()),
+		MessageVpn:          types.ObjectNull(model.MessageVpnObjectType().AttrTypes),
+		MessageVpns:         types.ListNull(model.MessageVpnObjectType()),
+		DmrClusterInfo:      types.ObjectNull(model.DmrClusterInfoObjectType().AttrTypes),
+		MessageSpool:        types.ObjectNull(model.MessageSpoolDetailsObjectType().AttrTypes),
+		Infrastructure:      types.ObjectNull(model.InfrastructureDetailsObjectType().AttrTypes),
+		AllowedActions:      types.ListNull(types.StringType),
+		AdoptExisting:       types.BoolValue(adoptExisting),
+		OnCreateFailure:     onCreateFailure,
+		CreateRetries:       createRetries,
+	})
+	if diags.HasError() {
+		t.Fatalf("could not build plan: %v", diags)
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	return plan, state
+}
+
+func TestCreateSavesServiceIdWhenCreationDoesNotFinish(t *testing.T) {
+	tests := []struct {
+		name            string
+		client          *stubServiceClient
+		cancel          bool
+		expectedSummary string
+	}{
+		{
+			name:            "creation failed",
+			client:          &stubServiceClient{getStatus: http.StatusOK, service: mc.Service{CreationState: ptr(mc.ServiceCreationStateFAILED)}},
+			expectedSummary: "Resource Creation FAILED",
+		},
+		{
+			name:            "unauthorized while waiting",
+			client:          &stubServiceClient{getStatus: http.StatusUnauthorized},
+			expectedSummary: "Authentication Failed During Service Status Check",
+		},
+		{
+			name:            "interrupted while waiting",
+			client:          &stubServiceClient{getStatus: http.StatusOK, service: mc.Service{CreationState: ptr(mc.ServiceCreationStateINPROGRESS)}},
+			cancel:          true,
+			expectedSummary: "Service Creation Interrupted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			tt.client.serviceId = "my-service-id"
+			r := &ServiceResource{APIClient: NewRetryableClient(tt.client, shared.RetryPolicy{}), APIPollingInterval: 60}
+			plan, state := plannedService(t, r, false)
+			resp := &resource.CreateResponse{State: state}
+
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an error")
+			}
+			var summaries []string
+			for _, d := range resp.Diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			joined := strings.Join(summaries, ", ")
+			if !strings.Contains(joined, tt.expectedSummary) || !strings.Contains(joined, "Service Creation Incomplete") {
+				t.Errorf("unexpected errors: %s", joined)
+			}
+
+			var id types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			if id.ValueString() != "my-service-id" {
+				t.Errorf("expected the service id to be saved to the state, got %s", id)
+			}
+		})
+	}
+}
+
+func TestCreateAdoptsExistingService(t *testing.T) {
+	existing := mc.Service{
+		Id:             ptr("existing-id"),
+		Name:           ptr("my-service"),
+		DatacenterId:   ptr("aws-ca-central-1a"),
+		ServiceClassId: ptr(mc.ServiceClassIdDEVELOPER),
+		CreationState:  ptr(mc.ServiceCreationStateCOMPLETED),
+	}
+	summary := mc.ServiceSummary{Id: existing.Id, Name: existing.Name}
+
+	tests := []struct {
+		name            string
+		adoptExisting   bool
+		datacenterId    string
+		services        []mc.ServiceSummary
+		expectedSummary string
+	}{
+		{
+			name:            "adoption not enabled",
+			services:        []mc.ServiceSummary{summary},
+			expectedSummary: "Resource Conflict",
+		},
+		{
+			name:          "matching service",
+			adoptExisting: true,
+			services:      []mc.ServiceSummary{summary, {Id: ptr("other-id"), Name: ptr("my-service-2")}},
+		},
+		{
+			name:            "immutable attributes differ",
+			adoptExisting:   true,
+			datacenterId:    "aws-us-east-1",
+			services:        []mc.ServiceSummary{summary},
+			expectedSummary: "Existing Service Does Not Match",
+		},
+		{
+			name:            "no service with the same name",
+			adoptExisting:   true,
+			expectedSummary: "No Service To Adopt",
+		},
+		{
+			name:            "several services with the same name",
+			adoptExisting:   true,
+			services:        []mc.ServiceSummary{summary, {Id: ptr("other-id"), Name: existing.Name}},
+			expectedSummary: "Multiple Services To Adopt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			service := existing
+			if tt.datacenterId != "" {
+				service.DatacenterId = &tt.datacenterId
+			}
+			client := &stubServiceClient{getStatus: http.StatusOK, conflict: true, services: tt.services, service: service}
+			r := &ServiceResource{APIClient: NewRetryableClient(client, shared.RetryPolicy{})}
+			plan, state := plannedService(t, r, tt.adoptExisting)
+			resp := &resource.CreateResponse{State: state}
+
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+			if tt.expectedSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+				}
+				var id types.String
+				resp.State.GetAttribute(ctx, path.Root("id"), &id)
+				if id.ValueString() != "existing-id" {
+					t.Errorf("expected the existing service to be adopted, got %s", id)
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.expectedSummary {
+				t.Fatalf("expected %q, got: %v", tt.expectedSummary, resp.Diagnostics.Errors())
+			}
+			if !resp.State.Raw.IsNull() {
+				t.Errorf("expected nothing to be saved to the state")
+			}
+		})
+	}
+}
+
+func TestCreateOnCreateFailure(t *testing.T) {
+	tests := []struct {
+		name              string
+		onCreateFailure   types.String
+		createRetries     types.Int64
+		failedCreations   int
+		expectedCreations int
+		expectedErrors    []string
+		expectDeleted     bool
+		expectedStateId   string
+		adoptExisting     bool
+	}{
+		{
+			name:              "keep by default",
+			onCreateFailure:   types.StringNull(),
+			failedCreations:   1,
+			expectedCreations: 1,
+			expectedErrors:    []string{"Resource Creation FAILED", "Service Creation Incomplete"},
+			expectedStateId:   "my-service-id",
+		},
+		{
+			name:              "delete without retries",
+			onCreateFailure:   types.StringValue(onCreateFailureDelete),
+			failedCreations:   1,
+			expectedCreations: 1,
+			expectedErrors:    []string{"Resource Creation FAILED", "Failed Service Deleted"},
+			expectDeleted:     true,
+		},
+		{
+			name:              "delete and retry until it succeeds",
+			onCreateFailure:   types.StringValue(onCreateFailureDelete),
+			createRetries:     types.Int64Value(2),
+			failedCreations:   2,
+			expectedCreations: 3,
+			expectedStateId:   "my-service-id",
+		},
+		{
+			name:              "delete and run out of retries",
+			onCreateFailure:   types.StringValue(onCreateFailureDelete),
+			createRetries:     types.Int64Value(1),
+			failedCreations:   5,
+			expectedCreations: 2,
+			expectedErrors:    []string{"Resource Creation FAILED", "Failed Service Deleted"},
+			expectDeleted:     true,
+		},
+		{
+			name:            "adopted service is not deleted",
+			onCreateFailure: types.StringValue(onCreateFailureDelete),
+			createRetries:   types.Int64Value(2),
+			failedCreations: 1,
+			adoptExisting:   true,
+			expectedErrors:  []string{"Resource Creation FAILED", "Adopted Service Not Deleted"},
+			expectedStateId: "my-service-id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &stubServiceClient{
+				serviceId:       "my-service-id",
+				getStatus:       http.StatusOK,
+				failedCreations: tt.failedCreations,
+				service: mc.Service{
+					Id:             ptr("my-service-id"),
+					Name:           ptr("my-service"),
+					DatacenterId:   ptr("aws-ca-central-1a"),
+					ServiceClassId: ptr(mc.ServiceClassIdDEVELOPER),
+				},
+				conflict: tt.adoptExisting,
+				services: []mc.ServiceSummary{{Id: ptr("my-service-id"), Name: ptr("my-service")}},
+			}
+			r := &ServiceResource{APIClient: NewRetryableClient(client, shared.RetryPolicy{})}
+			plan, state := plannedServiceWithFailurePolicy(t, r, tt.adoptExisting, tt.onCreateFailure, tt.createRetries)
+			resp := &resource.CreateResponse{State: state}
+
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+			var summaries []string
+			for _, d := range resp.Diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			if strings.Join(summaries, ", ") != strings.Join(tt.expectedErrors, ", ") {
+				t.Errorf("expected errors %v, got %v", tt.expectedErrors, resp.Diagnostics.Errors())
+			}
+			if len(tt.expectedErrors) > 0 && !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "Error ID: error-id. Error Message: no capacity") {
+				t.Errorf("expected the service error to be reported, got %s", resp.Diagnostics.Errors()[0].Detail())
+			}
+			if client.creations != tt.expectedCreations {
+				t.Errorf("expected %d creations, got %d", tt.expectedCreations, client.creations)
+			}
+			if client.deleted != tt.expectDeleted {
+				t.Errorf("expected deleted to be %t", tt.expectDeleted)
+			}
+
+			var id types.String
+			if !resp.State.Raw.IsNull() {
+				resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			}
+			if id.ValueString() != tt.expectedStateId {
+				t.Errorf("expected the state id to be %q, got %s", tt.expectedStateId, id)
+			}
+		})
+	}
+}
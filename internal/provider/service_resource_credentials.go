@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-solacecloud/internal/model"
+	"terraform-provider-solacecloud/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// storesCredentials reports whether the passwords of the service are kept in the Terraform state, which is the default.
+func storesCredentials(data ServiceResourceModel) bool {
+	return data.StoreCredentials.IsNull() || data.StoreCredentials.IsUnknown() || data.StoreCredentials.ValueBool()
+}
+
+// withoutCredentials returns a copy of the service without the management, messaging client and DMR cluster
+// passwords. The usernames are kept, they are not secret and other configurations refer to them.
+func withoutCredentials(service *missioncontrol.Service) *missioncontrol.Service {
+	if service == nil || service.Broker == nil {
+		return service
+	}
+
+	redacted := *service
+	broker := *service.Broker
+	redacted.Broker = &broker
+
+	broker.ManagementReadOnlyLoginCredential = withoutManagementPassword(broker.ManagementReadOnlyLoginCredential)
+	if broker.MsgVpns != nil {
+		msgVpns := make([]missioncontrol.MsgVpn, len(*broker.MsgVpns))
+		for i, msgVpn := range *broker.MsgVpns {
+			msgVpn.MissionControlManagerLoginCredential = withoutManagementPassword(msgVpn.MissionControlManagerLoginCredential)
+			msgVpn.ManagementAdminLoginCredential = withoutManagementPassword(msgVpn.ManagementAdminLoginCredential)
+			if msgVpn.ServiceLoginCredential != nil {
+				msgVpn.ServiceLoginCredential = &missioncontrol.LoginCredential{Username: msgVpn.ServiceLoginCredential.Username}
+			}
+			msgVpns[i] = msgVpn
+		}
+		broker.MsgVpns = &msgVpns
+	}
+	if broker.Cluster != nil {
+		cluster := *broker.Cluster
+		cluster.Password = nil
+		broker.Cluster = &cluster
+	}
+
+	return &redacted
+}
+
+func withoutManagementPassword(credential *missioncontrol.ManagementLoginCredential) *missioncontrol.ManagementLoginCredential {
+	if credential == nil {
+		return nil
+	}
+	return &missioncontrol.ManagementLoginCredential{Username: credential.Username}
+}
+
+// modifyPlanForCredentialStorage marks the attributes holding credentials as unknown when store_credentials changes,
+// as they keep their prior state value otherwise and the passwords would not be added to or removed from the state.
+func modifyPlanForCredentialStorage(ctx context.Context, state ServiceResourceModel, plan ServiceResourceModel, resp *resource.ModifyPlanResponse) {
+	if storesCredentials(state) == storesCredentials(plan) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("message_vpn"), types.ObjectUnknown(model.MessageVpnObjectType().AttrTypes))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("message_vpns"), types.ListUnknown(model.MessageVpnObjectType()))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("dmr_cluster"), types.ObjectUnknown(model.DmrClusterInfoObjectType().AttrTypes))...)
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-solacecloud/internal/shared"
+	"terraform-provider-solacecloud/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The prefixes of import IDs that look a service up instead of naming its identifier.
+const (
+	importByNamePrefix        = "name:"
+	importByEnvironmentPrefix = "env:"
+)
+
+// ImportState accepts the service identifier, or "name:<service name>" and "env:<environment name>/<service name>" which
+// are resolved to the identifier of the only service with that name.
+func (r *ServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = shared.MaskSensitiveLogFields(ctx)
+	serviceId, diags := r.resolveImportId(ctx, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), serviceId)...)
+}
+
+func (r *ServiceResource) resolveImportId(ctx context.Context, importId string) (string, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	var name, environmentName string
+	switch {
+	case strings.HasPrefix(importId, importByNamePrefix):
+		name = strings.TrimPrefix(importId, importByNamePrefix)
+	case strings.HasPrefix(importId, importByEnvironmentPrefix):
+		environmentName, name, _ = strings.Cut(strings.TrimPrefix(importId, importByEnvironmentPrefix), "/")
+		if environmentName == "" {
+			name = ""
+		}
+	default:
+		return importId, diagnostics
+	}
+
+	if name == "" {
+		diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Import ID %q must be a service identifier, \"name:<service name>\" or "+
+				"\"env:<environment name>/<service name>\".", importId),
+		)
+		return "", diagnostics
+	}
+
+	environmentId := ""
+	if environmentName != "" {
+		var diags diag.Diagnostics
+		environmentId, diags = lookupEnvironmentId(ctx, r.PlatformClient, environmentName)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return "", diagnostics
+		}
+	}
+
+	candidates, diags := r.findServicesByName(ctx, name, environmentId)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return "", diagnostics
+	}
+
+	switch len(candidates) {
+	case 0:
+		diagnostics.AddError(
+			"Service Not Found",
+			fmt.Sprintf("No service named %q was found%s.", name, inEnvironment(environmentName)),
+		)
+		return "", diagnostics
+	case 1:
+		tflog.Info(ctx, fmt.Sprintf("Importing service %s named %s", *candidates[0].Id, name))
+		return *candidates[0].Id, diagnostics
+	}
+
+	var lines []string
+	for _, candidate := range candidates {
+		line := "  " + *candidate.Id
+		if candidate.EnvironmentId != nil {
+			line += ", environment " + *candidate.EnvironmentId
+		}
+		if candidate.DatacenterId != nil {
+			line += ", datacenter " + *candidate.DatacenterId
+		}
+		lines = append(lines, line)
+	}
+	hint := "Import one of them by its identifier"
+	if environmentName == "" {
+		hint += `, or with "env:<environment name>/` + name + `"`
+	}
+	diagnostics.AddError(
+		"Multiple Services Found",
+		fmt.Sprintf("Found %d services named %q%s:\n%s\n\n%s.", len(candidates), name, inEnvironment(environmentName),
+			strings.Join(lines, "\n"), hint),
+	)
+	return "", diagnostics
+}
+
+func inEnvironment(environmentName string) string {
+	if environmentName == "" {
+		return ""
+	}
+	return fmt.Sprintf(" in environment %q", environmentName)
+}
+
+// findServicesByName lists the services with exactly this name, in the environment when one is given.
+func (r *ServiceResource) findServicesByName(ctx context.Context, name string, environmentId string) ([]missioncontrol.ServiceSummary, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	filter := fmt.Sprintf("name==%q", name)
+	if environmentId != "" {
+		filter += fmt.Sprintf(";environmentId==%q", environmentId)
+	}
+
+	apiClientResp, err := r.APIClient.GetServicesWithResponse(ctx, &missioncontrol.GetServicesParams{CustomAttributes: &filter})
+	if err != nil {
+		diagnostics.AddError(
+			"Error calling Solace Cloud API",
+			fmt.Sprintf("Could not look up the services named %q: %s", name, err),
+		)
+		return nil, diagnostics
+	}
+
+	errorHandler := shared.NewMissionControlErrorResponseAdaptor(
+		http.StatusOK,
+		apiClientResp.Body,
+		apiClientResp.HTTPResponse,
+		nil,
+		apiClientResp.JSON401,
+		apiClientResp.JSON403,
+		nil,
+		apiClientResp.JSON503,
+	)
+	if errorHandler.HandleError(&diagnostics) {
+		return nil, diagnostics
+	}
+
+	// The name filter supports wildcards, so only keep exact matches.
+	var services []missioncontrol.ServiceSummary
+	for _, service := range apiClientResp.JSON200.Data {
+		if service.Id == nil || service.Name == nil || *service.Name != name {
+			continue
+		}
+		if environmentId != "" && (service.EnvironmentId == nil || *service.EnvironmentId != environmentId) {
+			continue
+		}
+		services = append(services, service)
+	}
+	return services, diagnostics
+}
@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"terraform-provider-solacecloud/internal/model"
+	"terraform-provider-solacecloud/internal/shared"
+	mc "terraform-provider-solacecloud/missioncontrol"
+	"terraform-provider-solacecloud/platform"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// stubEnvironmentClient answers environment searches with a fixed response body.
+type stubEnvironmentClient struct {
+	body     string
+	searches int
+}
+
+func (c *stubEnvironmentClient) SearchEnvironmentsWithResponse(_ context.Context, _ *platform.SearchEnvironmentsParams, _ ...platform.RequestEditorFn) (*platform.SearchEnvironmentsResponse, error) {
+	c.searches++
+	return &platform.SearchEnvironmentsResponse{
+		Body:         []byte(c.body),
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil
+}
+
+func TestResolveImportId(t *testing.T) {
+	client := &stubServiceClient{services: []mc.ServiceSummary{
+		{Id: ptr("svc-1"), Name: ptr("my-broker"), EnvironmentId: ptr("env-prod"), DatacenterId: ptr("aws-ca-central-1a")},
+		{Id: ptr("svc-2"), Name: ptr("my-broker"), EnvironmentId: ptr("env-dev"), DatacenterId: ptr("aws-ca-central-1a")},
+		{Id: ptr("svc-3"), Name: ptr("other-broker"), EnvironmentId: ptr("env-prod")},
+		{Id: ptr("svc-4"), Name: ptr("other-broker-2"), EnvironmentId: ptr("env-prod")},
+	}}
+	r := &ServiceResource{
+		APIClient:      NewRetryableClient(client, shared.RetryPolicy{}),
+		PlatformClient: &stubEnvironmentClient{body: `{"data":[{"id":"env-prod","name":"Production"},{"id":"env-prod-2","name":"Production 2"}]}`},
+	}
+
+	tests := []struct {
+		importId        string
+		expectedId      string
+		expectedSummary string
+		expectedDetail  string
+	}{
+		{importId: "svc-1", expectedId: "svc-1"},
+		{importId: "name:other-broker", expectedId: "svc-3"},
+		{importId: "env:Production/my-broker", expectedId: "svc-1"},
+		{importId: "name:my-broker", expectedSummary: "Multiple Services Found", expectedDetail: "svc-1, environment env-prod"},
+		{importId: "name:missing", expectedSummary: "Service Not Found"},
+		{importId: "env:Staging/my-broker", expectedSummary: "Environment Not Found"},
+		{importId: "env:Production", expectedSummary: "Invalid Import ID"},
+		{importId: "name:", expectedSummary: "Invalid Import ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.importId, func(t *testing.T) {
+			serviceId, diags := r.resolveImportId(context.Background(), tt.importId)
+
+			if tt.expectedSummary == "" {
+				if diags.HasError() || serviceId != tt.expectedId {
+					t.Errorf("expected %q, got %q: %v", tt.expectedId, serviceId, diags)
+				}
+				return
+			}
+			if len(diags.Errors()) != 1 {
+				t.Fatalf("expected a single error, got: %v", diags)
+			}
+			if err := diags.Errors()[0]; err.Summary() != tt.expectedSummary || !strings.Contains(err.Detail(), tt.expectedDetail) {
+				t.Errorf("expected %q with %q, got %q: %s", tt.expectedSummary, tt.expectedDetail, err.Summary(), err.Detail())
+			}
+		})
+	}
+}
+
+func TestImportStateByName(t *testing.T) {
+	ctx := context.Background()
+	client := &stubServiceClient{services: []mc.ServiceSummary{{Id: ptr("svc-1"), Name: ptr("my-broker")}}}
+	r := &ServiceResource{APIClient: NewRetryableClient(client, shared.RetryPolicy{})}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "name:my-broker"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var id types.String
+	resp.State.GetAttribute(ctx, path.Root("id"), &id)
+	if id.ValueString() != "svc-1" {
+		t.Errorf("expected id svc-1, got %s", id)
+	}
+}
+
+// TestImportThenPlanIsEmpty imports a service of each shape, then plans the configuration it was created from: the
+// proposed new state is built the way Terraform does, from the configuration and the imported state for computed
+// attributes, and the plan must not change it.
+func TestImportThenPlanIsEmpty(t *testing.T) {
+	shapes := []struct {
+		name             string
+		serviceClassId   mc.ServiceClassId
+		routerNames      []string
+		customRouterName types.String
+	}{
+		{name: "standalone", serviceClassId: mc.ServiceClassIdENTERPRISE1KSTANDALONE, routerNames: []string{"svc1primary"}, customRouterName: types.StringNull()},
+		{name: "standalone custom router name", serviceClassId: mc.ServiceClassIdDEVELOPER, routerNames: []string{"myrouterprimarycn"}, customRouterName: types.StringValue("myrouter")},
+		{name: "high availability", serviceClassId: mc.ServiceClassIdENTERPRISE1KHIGHAVAILABILITY, routerNames: []string{"svc1primary", "svc1backup", "svc1monitoring"}, customRouterName: types.StringNull()},
+		{name: "high availability custom router name", serviceClassId: mc.ServiceClassIdENTERPRISE5KHIGHAVAILABILITY, routerNames: []string{"myrouterprimarycn", "myrouterbackupcn", "myroutermonitoringcn"}, customRouterName: types.StringValue("myrouter")},
+	}
+
+	for _, shape := range shapes {
+		t.Run(shape.name, func(t *testing.T) {
+			ctx := context.Background()
+			service := *testService(testMsgVpn("my-vpn"))
+			service.Id = ptr("svc1")
+			service.ServiceClassId = ptr(shape.serviceClassId)
+			service.MsgVpnName = ptr("my-vpn")
+			service.Locked = ptr(true)
+			service.OwnedBy = ptr("owner")
+			service.EnvironmentId = ptr("env-prod")
+			service.AdminState = ptr(mc.START)
+			service.CreationState = ptr(mc.ServiceCreationStateCOMPLETED)
+			service.Broker.RedundancyGroupSslEnabled = ptr(true)
+			service.Broker.Cluster.PrimaryRouterName = ptr(shape.routerNames[0])
+			if len(shape.routerNames) == 3 {
+				service.Broker.Cluster.BackupRouterName = ptr(shape.routerNames[1])
+				service.Broker.Cluster.MonitoringRouterName = ptr(shape.routerNames[2])
+			}
+			r := &ServiceResource{APIClient: NewRetryableClient(&stubServiceClient{getStatus: http.StatusOK, service: service}, shared.RetryPolicy{})}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+			importResp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: "svc1"}, importResp)
+			readResp := &resource.ReadResponse{State: importResp.State}
+			r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", readResp.Diagnostics)
+			}
+			state := readResp.State
+
+			config := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+			diags := config.Set(ctx, ServiceResourceModel{
+				Name:                types.StringValue("my-service"),
+				DatacenterId:        types.StringValue("aws-ca-central-1a"),
+				ServiceClassId:      types.StringValue(string(shape.serviceClassId)),
+				MessageVpnName:      types.StringValue("my-vpn"),
+				MaxSpoolUsage:       types.Int64Value(20),
+				ClusterName:         types.StringValue("my-cluster"),
+				OwnedBy:             types.StringValue("owner"),
+				Locked:              types.BoolValue(true),
+				MateLinkEncryption:  types.BoolValue(true),
+				CustomRouterName:    shape.customRouterName,
+				EnvironmentId:       types.StringValue("env-prod"),
+				ConnectionEndpoints: types.ListNull(model.ConnectionEndpointSchema().Type()),
+				MessageVpn:          types.ObjectNull(model.MessageVpnObjectType().AttrTypes),
+				MessageVpns:         types.ListNull(model.MessageVpnObjectType()),
+				DmrClusterInfo:      types.ObjectNull(model.DmrClusterInfoObjectType().AttrTypes),
+				MessageSpool:        types.ObjectNull(model.MessageSpoolDetailsObjectType().AttrTypes),
+				Infrastructure:      types.ObjectNull(model.InfrastructureDetailsObjectType().AttrTypes),
+				AllowedActions:      types.ListNull(types.StringType),
+			})
+			if diags.HasError() {
+				t.Fatalf("could not build config: %v", diags)
+			}
+
+			var stateValues, configValues map[string]tftypes.Value
+			if err := state.Raw.As(&stateValues); err != nil {
+				t.Fatal(err)
+			}
+			if err := config.Raw.As(&configValues); err != nil {
+				t.Fatal(err)
+			}
+			proposedValues := map[string]tftypes.Value{}
+			for name, attribute := range schemaResp.Schema.Attributes {
+				proposedValues[name] = configValues[name]
+				if configValues[name].IsNull() && attribute.IsComputed() {
+					proposedValues[name] = stateValues[name]
+				}
+				if !proposedValues[name].Equal(stateValues[name]) {
+					t.Errorf("%s: imported %s, configured %s", name, stateValues[name], configValues[name])
+				}
+			}
+			if t.Failed() {
+				return
+			}
+			proposed := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, proposedValues)}
+
+			planResp := &resource.ModifyPlanResponse{Plan: proposed}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config(config), Plan: proposed, State: state}, planResp)
+			if planResp.Diagnostics.HasError() || len(planResp.RequiresReplace) > 0 {
+				t.Errorf("unexpected plan changes: %v %v", planResp.Diagnostics, planResp.RequiresReplace)
+			}
+			if !planResp.Plan.Raw.Equal(state.Raw) {
+				t.Errorf("expected an empty plan")
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"terraform-provider-solacecloud/internal/shared"
+	mc "terraform-provider-solacecloud/missioncontrol"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// loggingServiceClient logs a password with the context it is given, as the API clients do when logging requests.
+type loggingServiceClient struct {
+	*stubServiceClient
+}
+
+func (c *loggingServiceClient) GetServiceWithResponse(ctx context.Context, serviceId string, params *mc.GetServiceParams, reqEditors ...mc.RequestEditorFn) (*mc.GetServiceResponse, error) {
+	tflog.Info(ctx, "Reading service", map[string]interface{}{"password": "resource-secret"})
+	return c.stubServiceClient.GetServiceWithResponse(ctx, serviceId, params, reqEditors...)
+}
+
+func TestServiceResourceMasksSensitiveLogFields(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	service := *testService(testMsgVpn("my-vpn"))
+	service.Id = ptr("svc1")
+	client := &loggingServiceClient{&stubServiceClient{getStatus: http.StatusOK, service: service}}
+	r := &ServiceResource{APIClient: NewRetryableClient(client, shared.RetryPolicy{})}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	importResp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "svc1"}, importResp)
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", readResp.Diagnostics)
+	}
+
+	logs := output.String()
+	if !strings.Contains(logs, "Reading service") {
+		t.Fatalf("expected the service to be read, got %s", logs)
+	}
+	if strings.Contains(logs, "resource-secret") {
+		t.Errorf("expected the password to be masked, got %s", logs)
+	}
+}
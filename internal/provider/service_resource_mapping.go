@@ -0,0 +1,474 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-solacecloud/internal/model"
+	"terraform-provider-solacecloud/internal/util"
+	"terraform-provider-solacecloud/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// mapServiceResponse copies a GetService response into the resource model.
+//
+// The API leaves parts of the response out while a service is still being provisioned, or when the caller is not
+// allowed to see them, so every field is treated as optional: a missing value keeps what is already known about the
+// service (or becomes null when nothing is), and problems are reported as warnings against the attribute they affect
+// instead of failing the whole read.
+func mapServiceResponse(ctx context.Context, service *missioncontrol.Service, data *ServiceResourceModel) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if service == nil {
+		diagnostics.AddError(
+			"Unexpected Service Response",
+			fmt.Sprintf("The response for service %s did not contain any service data.", data.Id.ValueString()),
+		)
+		return diagnostics
+	}
+	if !storesCredentials(*data) {
+		service = withoutCredentials(service)
+	}
+
+	data.DatacenterId = stringValueOrPrior(service.DatacenterId, data.DatacenterId)
+	if service.ServiceClassId != nil {
+		data.ServiceClassId = types.StringValue(string(*service.ServiceClassId))
+	} else {
+		data.ServiceClassId = stringValueOrPrior(nil, data.ServiceClassId)
+	}
+	data.Name = stringValueOrPrior(service.Name, data.Name)
+	if service.EventBrokerServiceVersion != "" {
+		data.EventBrokerVersion = types.StringValue(service.EventBrokerServiceVersion)
+	} else {
+		data.EventBrokerVersion = stringValueOrPrior(nil, data.EventBrokerVersion)
+	}
+	data.Locked = boolValueOrPrior(service.Locked, data.Locked)
+	data.EnvironmentId = stringValueOrPrior(service.EnvironmentId, data.EnvironmentId)
+	data.OwnedBy = stringValueOrPrior(service.OwnedBy, data.OwnedBy)
+
+	data.AdminState = util.EnumPointerValue(service.AdminState)
+	data.CreationState = util.EnumPointerValue(service.CreationState)
+	data.ErrorId = types.StringPointerValue(service.ErrorId)
+	data.ErrorMessage = types.StringPointerValue(service.ErrorMessage)
+	data.CreatedBy = types.StringPointerValue(service.CreatedBy)
+	data.CreatedTime = util.TimePointerValue(service.CreatedTime)
+	data.UpdatedBy = types.StringPointerValue(service.UpdatedBy)
+	data.UpdatedTime = util.TimePointerValue(service.UpdatedTime)
+	data.EventMeshId = types.StringPointerValue(service.EventMeshId)
+	data.InfrastructureId = types.StringPointerValue(service.InfrastructureId)
+	data.DefaultManagementHostname = types.StringPointerValue(service.DefaultManagementHostname)
+
+	var diags diag.Diagnostics
+	data.AllowedActions, diags = types.ListValueFrom(ctx, types.StringType, service.AllowedActions)
+	diagnostics.Append(diags...)
+
+	diagnostics.Append(mapMessageSpoolDetails(service.MessageSpoolDetails, data)...)
+	diagnostics.Append(mapInfrastructureDetails(service.InfrastructureDetails, data)...)
+	diagnostics.Append(mapBroker(ctx, service, data)...)
+	diagnostics.Append(mapConnectionEndpoints(ctx, service.ServiceConnectionEndpoints, data)...)
+
+	return diagnostics
+}
+
+func mapMessageSpoolDetails(details *missioncontrol.MessageSpoolDetails, data *ServiceResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.MessageSpool = types.ObjectNull(model.MessageSpoolDetailsObjectType().AttrTypes)
+	if details != nil {
+		data.MessageSpool, diags = model.MessageSpoolDetailsModel{
+			DefaultGbSize:    util.Int32PointerValue(details.DefaultGbSize),
+			ExpandedGbBilled: util.Int32PointerValue(details.ExpandedGbBilled),
+			TotalGbSize:      util.Int32PointerValue(details.TotalGbSize),
+		}.ToObjectValue()
+	}
+
+	return diags
+}
+
+func mapInfrastructureDetails(details *missioncontrol.InfrastructureDetails, data *ServiceResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Infrastructure details are not available on every service, leave them null rather than guessing.
+	data.Infrastructure = types.ObjectNull(model.InfrastructureDetailsObjectType().AttrTypes)
+	if details != nil {
+		data.Infrastructure, diags = model.InfrastructureDetailsModel{
+			PrimaryNodeHostname:    types.StringPointerValue(details.PrimaryNodeHostname),
+			BackupNodeHostname:     types.StringPointerValue(details.BackupNodeHostname),
+			MonitoringNodeHostname: types.StringPointerValue(details.MonitoringNodeHostname),
+		}.ToObjectValue()
+	}
+
+	return diags
+}
+
+// mapBroker fills in the attributes that come from the event broker details: the Message VPNs, the DMR cluster and the
+// broker level settings.
+func mapBroker(ctx context.Context, service *missioncontrol.Service, data *ServiceResourceModel) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	broker := service.Broker
+	if broker == nil {
+		diagnostics.AddAttributeWarning(
+			path.Root("message_vpn"),
+			"Event Broker Details Not Available",
+			fmt.Sprintf("The response for service %s did not include the event broker details, the service may still be "+
+				"provisioning. The Message VPN and DMR cluster details were left unchanged.", data.Id.ValueString()),
+		)
+		data.MessageVpn = objectValueOrNull(data.MessageVpn, model.MessageVpnObjectType().AttrTypes)
+		data.MessageVpns = listValueOrNull(data.MessageVpns, model.MessageVpnObjectType())
+		data.DmrClusterInfo = objectValueOrNull(data.DmrClusterInfo, model.DmrClusterInfoObjectType().AttrTypes)
+		data.MessageVpnName = stringValueOrPrior(nil, data.MessageVpnName)
+		data.MaxSpoolUsage = int64ValueOrPrior(nil, data.MaxSpoolUsage)
+		data.MateLinkEncryption = boolValueOrPrior(nil, data.MateLinkEncryption)
+		data.ClusterName = stringValueOrPrior(nil, data.ClusterName)
+		return diagnostics
+	}
+
+	data.MaxSpoolUsage = int64ValueOrPrior(broker.MaxSpoolUsage, data.MaxSpoolUsage)
+	data.MateLinkEncryption = boolValueOrPrior(broker.RedundancyGroupSslEnabled, data.MateLinkEncryption)
+
+	diagnostics.Append(mapMessageVpns(ctx, service, data)...)
+	diagnostics.Append(mapCluster(ctx, broker.Cluster, data)...)
+
+	return diagnostics
+}
+
+func mapMessageVpns(ctx context.Context, service *missioncontrol.Service, data *ServiceResourceModel) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	broker := service.Broker
+	if broker.MsgVpns == nil || len(*broker.MsgVpns) == 0 {
+		diagnostics.AddAttributeWarning(
+			path.Root("message_vpn"),
+			"No Message VPN Found",
+			fmt.Sprintf("The event broker details of service %s did not include any Message VPN, the service may still "+
+				"be provisioning. The Message VPN details were left unchanged.", data.Id.ValueString()),
+		)
+		data.MessageVpn = objectValueOrNull(data.MessageVpn, model.MessageVpnObjectType().AttrTypes)
+		data.MessageVpns = types.ListValueMust(model.MessageVpnObjectType(), []attr.Value{})
+		data.MessageVpnName = stringValueOrPrior(nil, data.MessageVpnName)
+		return diagnostics
+	}
+	msgVpns := *broker.MsgVpns
+
+	msgVpnValues := make([]attr.Value, 0, len(msgVpns))
+	for _, msgVpn := range msgVpns {
+		msgVpnValue, diags := mapMessageVpn(ctx, msgVpn, broker.ManagementReadOnlyLoginCredential)
+		diagnostics.Append(diags...)
+		if diags.HasError() {
+			return diagnostics
+		}
+		msgVpnValues = append(msgVpnValues, msgVpnValue)
+	}
+
+	var diags diag.Diagnostics
+	data.MessageVpns, diags = types.ListValue(model.MessageVpnObjectType(), msgVpnValues)
+	diagnostics.Append(diags...)
+
+	preferredName := data.MessageVpnName.ValueString()
+	if service.MsgVpnName != nil {
+		preferredName = *service.MsgVpnName
+	}
+	index, found := findMessageVpn(msgVpns, preferredName)
+	if !found && preferredName != "" && len(msgVpns) > 1 {
+		diagnostics.AddAttributeWarning(
+			path.Root("message_vpn"),
+			"Message VPN Not Found",
+			fmt.Sprintf("Service %s reports %d Message VPNs but none of them is named %q, message_vpn describes the "+
+				"first one reported. See message_vpns for the details of all of them.",
+				data.Id.ValueString(), len(msgVpns), preferredName),
+		)
+	}
+
+	data.MessageVpn = msgVpnValues[index].(basetypes.ObjectValue)
+	data.MessageVpnName = stringValueOrPrior(msgVpns[index].MsgVpnName, data.MessageVpnName)
+
+	return diagnostics
+}
+
+// findMessageVpn returns the index of the Message VPN with the given name, or the first one when there is no match.
+func findMessageVpn(msgVpns []missioncontrol.MsgVpn, name string) (int, bool) {
+	for i, msgVpn := range msgVpns {
+		if msgVpn.MsgVpnName != nil && *msgVpn.MsgVpnName == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func mapMessageVpn(ctx context.Context, msgVpn missioncontrol.MsgVpn, readOnlyCredential *missioncontrol.ManagementLoginCredential) (basetypes.ObjectValue, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	nullMessageVpn := types.ObjectNull(model.MessageVpnObjectType().AttrTypes)
+
+	if msgVpn.MissionControlManagerLoginCredential == nil {
+		tflog.Warn(ctx, "MissionControlManagerLoginCredential is not supported in this broker version. Setting null credentials.")
+	}
+	managerManagementCredential, diags := managementCredentialValue(msgVpn.MissionControlManagerLoginCredential)
+	diagnostics.Append(diags...)
+	editorManagementCredential, diags := managementCredentialValue(msgVpn.ManagementAdminLoginCredential)
+	diagnostics.Append(diags...)
+	viewerManagementCredential, diags := managementCredentialValue(readOnlyCredential)
+	diagnostics.Append(diags...)
+	messagingClientCredential := types.ObjectNull(model.BasicAuthCredentialObjectType().AttrTypes)
+	if msgVpn.ServiceLoginCredential != nil {
+		messagingClientCredential, diags = model.BasicAuthCredentialModel{
+			Username: types.StringPointerValue(msgVpn.ServiceLoginCredential.Username),
+			Password: types.StringPointerValue(msgVpn.ServiceLoginCredential.Password),
+		}.ToObjectValue()
+		diagnostics.Append(diags...)
+	}
+	if diagnostics.HasError() {
+		return nullMessageVpn, diagnostics
+	}
+
+	sempOverMessageBus := types.ObjectNull(model.SempOverMessageBusObjectType().AttrTypes)
+	if msgVpn.SempOverMessageBus != nil {
+		sempOverMessageBus, diags = model.SempOverMessageBusModel{
+			Enabled:                    types.BoolPointerValue(msgVpn.SempOverMessageBus.SempOverMsgBusEnabled),
+			ShowCommandsEnabled:        types.BoolPointerValue(msgVpn.SempOverMessageBus.SempAccessToShowCmdsEnabled),
+			AdminCommandsEnabled:       types.BoolPointerValue(msgVpn.SempOverMessageBus.SempAccessToAdminCmdsEnabled),
+			ClientAdminCommandsEnabled: types.BoolPointerValue(msgVpn.SempOverMessageBus.SempAccessToClientAdminCmdsEnabled),
+			CacheCommandsEnabled:       types.BoolPointerValue(msgVpn.SempOverMessageBus.SempAccessToCacheCmdsEnabled),
+		}.ToObjectValue()
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return nullMessageVpn, diagnostics
+		}
+	}
+
+	msgVpnValue, diags := model.MessageVpnModel{
+		Name:                            types.StringPointerValue(msgVpn.MsgVpnName),
+		AuthenticationBasicEnabled:      types.BoolPointerValue(msgVpn.AuthenticationBasicEnabled),
+		AuthenticationBasicType:         util.EnumPointerValue(msgVpn.AuthenticationBasicType),
+		AuthenticationClientCertEnabled: types.BoolPointerValue(msgVpn.AuthenticationClientCertEnabled),
+		AuthenticationClientCertValidateDateEnabled: types.BoolPointerValue(msgVpn.AuthenticationClientCertValidateDateEnabled),
+		AuthenticationOauthEnabled:                  types.BoolPointerValue(msgVpn.AuthenticationOauthEnabled),
+		Enabled:                                     types.BoolPointerValue(msgVpn.Enabled),
+		EventLargeMsgThreshold:                      util.Int32PointerValue(msgVpn.EventLargeMsgThreshold),
+		SubDomainName:                               types.StringPointerValue(msgVpn.SubDomainName),
+		SempOverMessageBus:                          sempOverMessageBus,
+		MaxConnectionCount:                          util.Int32PointerValue(msgVpn.MaxConnectionCount),
+		MaxEgressFlowCount:                          util.Int32PointerValue(msgVpn.MaxEgressFlowCount),
+		MaxEndpointCount:                            util.Int32PointerValue(msgVpn.MaxEndpointCount),
+		MaxIngressFlowCount:                         util.Int32PointerValue(msgVpn.MaxIngressFlowCount),
+		MaxMsgSpoolUsage:                            util.Int32PointerValue(msgVpn.MaxMsgSpoolUsage),
+		MaxSubscriptionCount:                        util.Int32PointerValue(msgVpn.MaxSubscriptionCount),
+		MaxTransactedSessionCount:                   util.Int32PointerValue(msgVpn.MaxTransactedSessionCount),
+		MaxTransactionCount:                         util.Int32PointerValue(msgVpn.MaxTransactionCount),
+		TruststoreUri:                               types.StringPointerValue(msgVpn.TruststoreUri),
+		ManagerManagementCredential:                 managerManagementCredential,
+		EditorManagementCredential:                  editorManagementCredential,
+		ViewerManagementCredential:                  viewerManagementCredential,
+		MessagingClientCredential:                   messagingClientCredential,
+	}.ToObjectValue()
+	diagnostics.Append(diags...)
+
+	return msgVpnValue, diagnostics
+}
+
+func managementCredentialValue(credential *missioncontrol.ManagementLoginCredential) (basetypes.ObjectValue, diag.Diagnostics) {
+	if credential == nil {
+		return types.ObjectNull(model.BasicAuthCredentialObjectType().AttrTypes), nil
+	}
+	return model.BasicAuthCredentialModel{
+		Username: types.StringPointerValue(credential.Username),
+		Password: types.StringPointerValue(credential.Password),
+	}.ToObjectValue()
+}
+
+func mapCluster(ctx context.Context, cluster *missioncontrol.Cluster, data *ServiceResourceModel) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if cluster == nil {
+		diagnostics.AddAttributeWarning(
+			path.Root("dmr_cluster"),
+			"DMR Cluster Details Not Available",
+			fmt.Sprintf("The event broker details of service %s did not include the DMR cluster, "+
+				"the DMR cluster details were left unchanged.", data.Id.ValueString()),
+		)
+		data.DmrClusterInfo = objectValueOrNull(data.DmrClusterInfo, model.DmrClusterInfoObjectType().AttrTypes)
+		data.ClusterName = stringValueOrPrior(nil, data.ClusterName)
+		return diagnostics
+	}
+
+	supportedDmrAuthenticationModes, diags := types.ListValueFrom(ctx, types.StringType, cluster.SupportedAuthenticationMode)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	data.DmrClusterInfo, diags = model.DmrClusterInfoModel{
+		Name:                         types.StringPointerValue(cluster.Name),
+		Password:                     types.StringPointerValue(cluster.Password),
+		RemoteAddress:                types.StringPointerValue(cluster.RemoteAddress),
+		PrimaryRouterName:            types.StringPointerValue(cluster.PrimaryRouterName),
+		BackupRouterName:             types.StringPointerValue(cluster.BackupRouterName),
+		MonitoringRouterName:         types.StringPointerValue(cluster.MonitoringRouterName),
+		SupportedAuthenticationModes: supportedDmrAuthenticationModes,
+	}.ToObjectValue()
+	diagnostics.Append(diags...)
+
+	data.ClusterName = stringValueOrPrior(cluster.Name, data.ClusterName)
+
+	// The API does not return custom_router_name, it is worked out from the router names instead. When they do not
+	// follow a known naming scheme, the value already in state is kept.
+	if customRouterName, ok := determineCustomRouterName(cluster); ok {
+		data.CustomRouterName = customRouterName
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Could not determine custom_router_name of service %s from its router names",
+			data.Id.ValueString()))
+	}
+
+	return diagnostics
+}
+
+// routerNameRoles are the node roles that router names end with. A standalone service only has a primary router, a
+// high availability service also has a backup and a monitoring router.
+var routerNameRoles = []string{"primary", "backup", "monitoring"}
+
+// customRouterNameSuffix ends the router names built from a custom router name.
+const customRouterNameSuffix = "cn"
+
+// determineCustomRouterName works out custom_router_name from the router names of a service's DMR cluster. A custom
+// router name is followed by the node role and "cn", "customprimarycn", while the default prefix, the service ID, is
+// only followed by the node role, "bd37t1d5h4kprimary", which maps to a null custom_router_name. It returns false when
+// the router names do not consistently follow one of these schemes.
+func determineCustomRouterName(cluster *missioncontrol.Cluster) (types.String, bool) {
+	if cluster == nil {
+		return types.StringNull(), false
+	}
+	routerNames := []*string{cluster.PrimaryRouterName, cluster.BackupRouterName, cluster.MonitoringRouterName}
+
+	found := false
+	custom := false
+	prefix := ""
+	for i, routerName := range routerNames {
+		if routerName == nil || *routerName == "" {
+			continue
+		}
+
+		role := routerNameRoles[i]
+		var routerPrefix string
+		var routerCustom bool
+		switch {
+		case strings.HasSuffix(*routerName, role+customRouterNameSuffix):
+			routerPrefix, routerCustom = strings.TrimSuffix(*routerName, role+customRouterNameSuffix), true
+		case strings.HasSuffix(*routerName, role):
+			routerPrefix, routerCustom = strings.TrimSuffix(*routerName, role), false
+		default:
+			return types.StringNull(), false
+		}
+		if routerPrefix == "" || found && (routerPrefix != prefix || routerCustom != custom) {
+			return types.StringNull(), false
+		}
+		found, custom, prefix = true, routerCustom, routerPrefix
+	}
+
+	if !found {
+		return types.StringNull(), false
+	}
+	if !custom {
+		return types.StringNull(), true
+	}
+	return types.StringValue(prefix), true
+}
+
+func mapConnectionEndpoints(ctx context.Context, endpoints *[]missioncontrol.ConnectionEndpoint, data *ServiceResourceModel) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if endpoints == nil {
+		data.ConnectionEndpoints = listValueOrNull(data.ConnectionEndpoints, model.ConnectionEndpointSchema().Type())
+		return diagnostics
+	}
+
+	connectionEndpointValuesList := make([]attr.Value, 0, len(*endpoints))
+	for _, serviceConnectionEndpoint := range *endpoints {
+		portsObject, diags := model.ToObjectValue(serviceConnectionEndpoint.Ports)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return diagnostics
+		}
+
+		hostnameList, diags := types.ListValueFrom(ctx, types.StringType, serviceConnectionEndpoint.HostNames)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return diagnostics
+		}
+
+		connectionEndpointValue, diags := model.ConnectionEndpointModel{
+			Id:             types.StringPointerValue(serviceConnectionEndpoint.Id),
+			Name:           types.StringValue(serviceConnectionEndpoint.Name),
+			Description:    types.StringPointerValue(serviceConnectionEndpoint.Description),
+			AccessType:     types.StringValue(string(serviceConnectionEndpoint.AccessType)),
+			K8SServiceType: util.EnumPointerValue(serviceConnectionEndpoint.K8sServiceType),
+			K8SServiceId:   types.StringPointerValue(serviceConnectionEndpoint.K8sServiceId),
+			Hostnames:      hostnameList,
+			Ports:          portsObject,
+		}.ToObjectValue()
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return diagnostics
+		}
+
+		connectionEndpointValuesList = append(connectionEndpointValuesList, connectionEndpointValue)
+	}
+
+	var diags diag.Diagnostics
+	data.ConnectionEndpoints, diags = types.ListValue(model.ConnectionEndpointSchema().Type(), connectionEndpointValuesList)
+	diagnostics.Append(diags...)
+
+	return diagnostics
+}
+
+// stringValueOrPrior returns the value reported by the API, falling back to the prior known value when it is missing.
+func stringValueOrPrior(value *string, prior types.String) types.String {
+	if value != nil {
+		return types.StringValue(*value)
+	}
+	if prior.IsUnknown() {
+		return types.StringNull()
+	}
+	return prior
+}
+
+func boolValueOrPrior(value *bool, prior types.Bool) types.Bool {
+	if value != nil {
+		return types.BoolValue(*value)
+	}
+	if prior.IsUnknown() {
+		return types.BoolNull()
+	}
+	return prior
+}
+
+func int64ValueOrPrior(value *int32, prior types.Int64) types.Int64 {
+	if value != nil {
+		return types.Int64Value(int64(*value))
+	}
+	if prior.IsUnknown() {
+		return types.Int64Null()
+	}
+	return prior
+}
+
+// objectValueOrNull keeps a known prior object, otherwise returns a null object of the given type.
+func objectValueOrNull(prior basetypes.ObjectValue, attrTypes map[string]attr.Type) basetypes.ObjectValue {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.ObjectNull(attrTypes)
+	}
+	return prior
+}
+
+// listValueOrNull keeps a known prior list, otherwise returns a null list of the given element type.
+func listValueOrNull(prior types.List, elementType attr.Type) types.List {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.ListNull(elementType)
+	}
+	return prior
+}
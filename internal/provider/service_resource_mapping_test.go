@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-solacecloud/internal/model"
+	"terraform-provider-solacecloud/missioncontrol"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func testMsgVpn(name string) missioncontrol.MsgVpn {
+	return missioncontrol.MsgVpn{
+		MsgVpnName:       ptr(name),
+		MaxMsgSpoolUsage: ptr(int32(10)),
+		ServiceLoginCredential: &missioncontrol.LoginCredential{
+			Username: ptr(name + "-user"),
+			Password: ptr("secret"),
+		},
+	}
+}
+
+func testService(msgVpns ...missioncontrol.MsgVpn) *missioncontrol.Service {
+	return &missioncontrol.Service{
+		Name:                      ptr("my-service"),
+		DatacenterId:              ptr("aws-ca-central-1a"),
+		ServiceClassId:            ptr(missioncontrol.ServiceClassIdDEVELOPER),
+		EventBrokerServiceVersion: "10.8",
+		MsgVpnName:                ptr("default"),
+		Broker: &missioncontrol.Broker{
+			MaxSpoolUsage: ptr(int32(20)),
+			MsgVpns:       &msgVpns,
+			Cluster: &missioncontrol.Cluster{
+				Name:              ptr("my-cluster"),
+				PrimaryRouterName: ptr("myrouterprimarycn"),
+			},
+		},
+	}
+}
+
+func TestMapServiceResponse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("single message vpn", func(t *testing.T) {
+		data := ServiceResourceModel{Id: types.StringValue("id")}
+		diags := mapServiceResponse(ctx, testService(testMsgVpn("default")), &data)
+		if diags.HasError() || diags.WarningsCount() > 0 {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if data.MessageVpnName.ValueString() != "default" {
+			t.Errorf("expected message_vpn_name default, got %s", data.MessageVpnName)
+		}
+		if len(data.MessageVpns.Elements()) != 1 {
+			t.Errorf("expected 1 message vpn, got %d", len(data.MessageVpns.Elements()))
+		}
+		if data.CustomRouterName.ValueString() != "myrouter" {
+			t.Errorf("expected custom_router_name myrouter, got %s", data.CustomRouterName)
+		}
+		if data.MaxSpoolUsage.ValueInt64() != 20 {
+			t.Errorf("expected max_spool_usage 20, got %s", data.MaxSpoolUsage)
+		}
+		// nil pointers map to nulls rather than panicking
+		if !data.ConnectionEndpoints.IsNull() || !data.AdminState.IsNull() {
+			t.Errorf("expected missing values to be null")
+		}
+	})
+
+	t.Run("multiple message vpns selects the service's", func(t *testing.T) {
+		data := ServiceResourceModel{Id: types.StringValue("id")}
+		diags := mapServiceResponse(ctx, testService(testMsgVpn("other"), testMsgVpn("default")), &data)
+		if diags.HasError() || diags.WarningsCount() > 0 {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if len(data.MessageVpns.Elements()) != 2 {
+			t.Errorf("expected 2 message vpns, got %d", len(data.MessageVpns.Elements()))
+		}
+		var msgVpn model.MessageVpnModel
+		data.MessageVpn.As(ctx, &msgVpn, basetypes.ObjectAsOptions{})
+		if msgVpn.Name.ValueString() != "default" {
+			t.Errorf("expected message_vpn to describe default, got %s", msgVpn.Name)
+		}
+	})
+
+	t.Run("multiple message vpns without a match", func(t *testing.T) {
+		service := testService(testMsgVpn("one"), testMsgVpn("two"))
+		data := ServiceResourceModel{Id: types.StringValue("id")}
+		diags := mapServiceResponse(ctx, service, &data)
+		if diags.HasError() || diags.WarningsCount() != 1 {
+			t.Fatalf("expected a single warning, got: %v", diags)
+		}
+		if data.MessageVpnName.ValueString() != "one" {
+			t.Errorf("expected to fall back to the first message vpn, got %s", data.MessageVpnName)
+		}
+	})
+
+	t.Run("zero message vpns keeps prior state", func(t *testing.T) {
+		prior := ServiceResourceModel{Id: types.StringValue("id"), MessageVpnName: types.StringValue("default")}
+		mapServiceResponse(ctx, testService(testMsgVpn("default")), &prior)
+
+		data := prior
+		diags := mapServiceResponse(ctx, testService(), &data)
+		if diags.HasError() || diags.WarningsCount() != 1 {
+			t.Fatalf("expected a single warning, got: %v", diags)
+		}
+		if diags[0].(diag.DiagnosticWithPath).Path().String() != "message_vpn" {
+			t.Errorf("expected the warning on message_vpn, got %v", diags[0])
+		}
+		if !data.MessageVpn.Equal(prior.MessageVpn) {
+			t.Errorf("expected message_vpn to be unchanged")
+		}
+		if len(data.MessageVpns.Elements()) != 0 {
+			t.Errorf("expected no message vpns, got %d", len(data.MessageVpns.Elements()))
+		}
+	})
+
+	t.Run("missing broker", func(t *testing.T) {
+		service := testService()
+		service.Broker = nil
+		data := ServiceResourceModel{
+			Id:            types.StringValue("id"),
+			MaxSpoolUsage: types.Int64Unknown(),
+			MessageVpn:    types.ObjectUnknown(model.MessageVpnObjectType().AttrTypes),
+		}
+		diags := mapServiceResponse(ctx, service, &data)
+		if diags.HasError() || diags.WarningsCount() != 1 {
+			t.Fatalf("expected a single warning, got: %v", diags)
+		}
+		if !data.MessageVpn.IsNull() || !data.DmrClusterInfo.IsNull() || !data.MaxSpoolUsage.IsNull() {
+			t.Errorf("expected unknown broker values to become null")
+		}
+		if data.Name.ValueString() != "my-service" {
+			t.Errorf("expected the service details to still be mapped, got %s", data.Name)
+		}
+	})
+
+	t.Run("missing cluster", func(t *testing.T) {
+		service := testService(testMsgVpn("default"))
+		service.Broker.Cluster = nil
+		data := ServiceResourceModel{Id: types.StringValue("id"), CustomRouterName: types.StringValue("custom")}
+		diags := mapServiceResponse(ctx, service, &data)
+		if diags.HasError() || diags.WarningsCount() != 1 {
+			t.Fatalf("expected a single warning, got: %v", diags)
+		}
+		if data.CustomRouterName.ValueString() != "custom" {
+			t.Errorf("expected custom_router_name to be unchanged, got %s", data.CustomRouterName)
+		}
+	})
+
+	t.Run("missing service", func(t *testing.T) {
+		data := ServiceResourceModel{Id: types.StringValue("id")}
+		if diags := mapServiceResponse(ctx, nil, &data); !diags.HasError() {
+			t.Errorf("expected an error")
+		}
+	})
+}
+
+func TestDetermineCustomRouterName(t *testing.T) {
+	tests := []struct {
+		name       string
+		cluster    *missioncontrol.Cluster
+		expected   types.String
+		determined bool
+	}{
+		{name: "standalone default", cluster: &missioncontrol.Cluster{PrimaryRouterName: ptr("bd37t1d5h4kprimary")}, expected: types.StringNull(), determined: true},
+		{name: "standalone custom", cluster: &missioncontrol.Cluster{PrimaryRouterName: ptr("customprimarycn")}, expected: types.StringValue("custom"), determined: true},
+		{name: "high availability default", cluster: &missioncontrol.Cluster{PrimaryRouterName: ptr("bd37t1d5h4kprimary"),
+			BackupRouterName: ptr("bd37t1d5h4kbackup"), MonitoringRouterName: ptr("bd37t1d5h4kmonitoring")}, expected: types.StringNull(), determined: true},
+		{name: "high availability custom", cluster: &missioncontrol.Cluster{PrimaryRouterName: ptr("customprimarycn"),
+			BackupRouterName: ptr("custombackupcn"), MonitoringRouterName: ptr("custommonitoringcn")}, expected: types.StringValue("custom"), determined: true},
+		{name: "custom name containing a role", cluster: &missioncontrol.Cluster{PrimaryRouterName: ptr("primarycnprimarycn")}, expected: types.StringValue("primarycn"), determined: true},
+		{name: "inconsistent prefixes", cluster: &missioncontrol.Cluster{PrimaryRouterName: ptr("customprimarycn"), BackupRouterName: ptr("otherbackupcn")}, determined: false},
+		{name: "unknown scheme", cluster: &missioncontrol.Cluster{PrimaryRouterName: ptr("primary-router")}, determined: false},
+		{name: "no router names", cluster: &missioncontrol.Cluster{}, determined: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, determined := determineCustomRouterName(tt.cluster)
+			if determined != tt.determined || determined && !got.Equal(tt.expected) {
+				t.Errorf("expected %s (%t), got %s (%t)", tt.expected, tt.determined, got, determined)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-solacecloud/internal/shared"
+	"terraform-provider-solacecloud/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithModifyPlan = &ServiceResource{}
+
+// serviceResourceType names the resource in the errors of the permission checks.
+const serviceResourceType = "solacecloud_service"
+
+// immutableAttributeChange describes a planned change to an attribute that cannot be updated in place.
+type immutableAttributeChange struct {
+	path       path.Path
+	stateValue types.String
+	planValue  types.String
+}
+
+func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = shared.MaskSensitiveLogFields(ctx)
+	// Nothing but the permissions to check on destroy.
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(r.Permissions.Check(serviceResourceType, shared.ReadServicesPermission, shared.DeleteServicesPermission)...)
+		return
+	}
+
+	var plan ServiceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state ServiceResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	r.modifyPlanForProviderDefaults(ctx, req.Config, req.State.Raw.IsNull(), state, &plan, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(r.Permissions.Check(serviceResourceType, shared.ReadServicesPermission, shared.CreateServicesPermission)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.validatePlanAgainstMissionControl(ctx, plan)...)
+		return
+	}
+
+	r.modifyPlanForImmutableChanges(state, plan, resp)
+	modifyPlanForServiceState(ctx, state, resp)
+	modifyPlanForCredentialStorage(ctx, state, plan, resp)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A replacement creates a new service, check it like any other.
+	if len(resp.RequiresReplace) > 0 {
+		resp.Diagnostics.Append(r.Permissions.Check(serviceResourceType,
+			shared.ReadServicesPermission, shared.CreateServicesPermission, shared.DeleteServicesPermission)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.validatePlanAgainstMissionControl(ctx, plan)...)
+		return
+	}
+
+	requirements := []shared.PermissionRequirement{shared.ReadServicesPermission}
+	if !resp.Plan.Raw.Equal(req.State.Raw) {
+		requirements = append(requirements, shared.UpdateServicesPermission)
+	}
+	resp.Diagnostics.Append(r.Permissions.Check(serviceResourceType, requirements...)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.validateMaxSpoolUsageChange(ctx, state, plan)...)
+}
+
+// modifyPlanForImmutableChanges decides whether changes to immutable attributes fail the plan or replace the service,
+// resolving the provider-wide setting and protecting locked services. When the resource explicitly opts out of
+// replacement, ImmutableStringPlanModifier already failed the plan for the string attributes, so only
+// custom_router_name, whose plan modifier leaves the error to this method, is reported here.
+func (r *ServiceResource) modifyPlanForImmutableChanges(state ServiceResourceModel, plan ServiceResourceModel, resp *resource.ModifyPlanResponse) {
+	changes := immutableAttributeChanges(state, plan)
+	if len(changes) == 0 {
+		return
+	}
+
+	replace := r.ReplaceOnImmutableChange
+	optedOut := false
+	if !plan.ReplaceOnImmutableChange.IsNull() && !plan.ReplaceOnImmutableChange.IsUnknown() {
+		replace = plan.ReplaceOnImmutableChange.ValueBool()
+		optedOut = !replace
+	}
+
+	if !replace {
+		for _, change := range changes {
+			if optedOut && !change.path.Equal(path.Root("custom_router_name")) {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(
+				change.path,
+				"Immutable Attribute Change",
+				fmt.Sprintf("You cannot change this attribute after resource creation. "+
+					"State value: %q, Planned value: %q",
+					change.stateValue.ValueString(), change.planValue.ValueString()),
+			)
+		}
+		return
+	}
+
+	if state.Locked.ValueBool() || plan.Locked.ValueBool() {
+		for _, change := range changes {
+			resp.Diagnostics.AddAttributeError(
+				change.path,
+				"Locked Service Cannot Be Replaced",
+				fmt.Sprintf("Changing this attribute requires the service to be replaced, but the service is locked. "+
+					"Set locked = false and apply before changing it. State value: %q, Planned value: %q",
+					change.stateValue.ValueString(), change.planValue.ValueString()),
+			)
+		}
+		return
+	}
+
+	for _, change := range changes {
+		resp.RequiresReplace = append(resp.RequiresReplace, change.path)
+	}
+}
+
+// immutableAttributeChanges lists the immutable attributes whose planned value differs from the state. It follows the
+// same rules as the attribute plan modifiers: values missing from either the state or the plan are not changes, except
+// for custom_router_name which may not be added or removed either.
+func immutableAttributeChanges(state ServiceResourceModel, plan ServiceResourceModel) []immutableAttributeChange {
+	candidates := []immutableAttributeChange{
+		{path.Root("datacenter_id"), state.DatacenterId, plan.DatacenterId},
+		{path.Root("service_class_id"), state.ServiceClassId, plan.ServiceClassId},
+		{path.Root("message_vpn_name"), state.MessageVpnName, plan.MessageVpnName},
+		{path.Root("cluster_name"), state.ClusterName, plan.ClusterName},
+		{path.Root("environment_id"), state.EnvironmentId, plan.EnvironmentId},
+		{path.Root("event_broker_version"), state.EventBrokerVersion, plan.EventBrokerVersion},
+	}
+
+	var changes []immutableAttributeChange
+	for _, candidate := range candidates {
+		if candidate.stateValue.ValueString() == "" || candidate.planValue.ValueString() == "" {
+			continue
+		}
+		if candidate.stateValue.ValueString() != candidate.planValue.ValueString() {
+			changes = append(changes, candidate)
+		}
+	}
+
+	if !plan.CustomRouterName.IsUnknown() && !plan.CustomRouterName.Equal(state.CustomRouterName) {
+		changes = append(changes, immutableAttributeChange{path.Root("custom_router_name"), state.CustomRouterName, plan.CustomRouterName})
+	}
+
+	return changes
+}
+
+// modifyPlanForServiceState plans the replacement of services that failed to be created or are being destroyed, since
+// they look healthy to Terraform otherwise. The state attribute is marked as unknown so that Terraform considers it
+// changed, which is what makes the requested replacement take effect.
+func modifyPlanForServiceState(ctx context.Context, state ServiceResourceModel, resp *resource.ModifyPlanResponse) {
+	var statePath path.Path
+	var reason string
+	switch {
+	case state.CreationState.ValueString() == string(missioncontrol.ServiceCreationStateFAILED):
+		statePath = path.Root("creation_state")
+		reason = fmt.Sprintf("The creation of service %s FAILED.%s", state.Id.ValueString(),
+			serviceErrorDetails(state.ErrorId.ValueStringPointer(), state.ErrorMessage.ValueStringPointer()))
+	case state.AdminState.ValueString() == string(missioncontrol.DESTROY):
+		statePath = path.Root("admin_state")
+		reason = fmt.Sprintf("Service %s is being destroyed.", state.Id.ValueString())
+	case state.AdminState.ValueString() == string(missioncontrol.STOP):
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("admin_state"),
+			"Service Is Stopped",
+			fmt.Sprintf("Service %s is stopped and does not accept connections. The Mission Control API does not offer "+
+				"a way to start it, start it from the Solace Cloud Console.", state.Id.ValueString()),
+		)
+		return
+	default:
+		return
+	}
+
+	if state.Locked.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			statePath,
+			"Locked Service Not Replaced",
+			reason+" It would be replaced, but it is locked. Set locked = false to allow its replacement.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, statePath, types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, statePath)
+	resp.Diagnostics.AddAttributeWarning(
+		statePath,
+		"Service Will Be Replaced",
+		reason+" It is planned to be deleted and created again.",
+	)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-solacecloud/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// modifyPlanForProviderDefaults sets the environment, datacenter and service class that the configuration of a
+// service leaves unset to the defaults of the provider, so that the plan shows the inherited values. An attribute set
+// in the configuration, even to a value only known after apply, is left as is. An existing service keeps the values of
+// its state, so that changing a default of the provider does not replace every service that inherited it.
+func (r *ServiceResource) modifyPlanForProviderDefaults(ctx context.Context, config tfsdk.Config, creating bool, state ServiceResourceModel, plan *ServiceResourceModel, resp *resource.ModifyPlanResponse) {
+	if config.Raw.IsNull() {
+		return
+	}
+
+	if configNull(ctx, config, path.Root("datacenter_id"), &resp.Diagnostics) {
+		switch {
+		case keepPriorValue(ctx, path.Root("datacenter_id"), state.DatacenterId, &plan.DatacenterId, resp):
+		case r.DefaultDatacenterId != "":
+			plan.DatacenterId = types.StringValue(r.DefaultDatacenterId)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("datacenter_id"), plan.DatacenterId)...)
+		case creating:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("datacenter_id"),
+				"Missing Datacenter",
+				"The datacenter of the service is not set. Set datacenter_id on the service, or default_datacenter_id on the provider.",
+			)
+		}
+	}
+
+	if configNull(ctx, config, path.Root("service_class_id"), &resp.Diagnostics) &&
+		!keepPriorValue(ctx, path.Root("service_class_id"), state.ServiceClassId, &plan.ServiceClassId, resp) {
+		serviceClassId := r.DefaultServiceClassId
+		if serviceClassId == "" {
+			serviceClassId = defaultServiceClassId
+		}
+		plan.ServiceClassId = types.StringValue(serviceClassId)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("service_class_id"), plan.ServiceClassId)...)
+	}
+
+	if r.DefaultEnvironment != nil && configNull(ctx, config, path.Root("environment_id"), &resp.Diagnostics) &&
+		!keepPriorValue(ctx, path.Root("environment_id"), state.EnvironmentId, &plan.EnvironmentId, resp) &&
+		r.hasEnvironments(ctx, plan.DatacenterId) {
+		environmentId, diags := r.DefaultEnvironment.Id(func(nameOrId string) (string, diag.Diagnostics) {
+			return lookupEnvironmentIdByNameOrId(ctx, r.PlatformClient, nameOrId)
+		})
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		plan.EnvironmentId = types.StringValue(environmentId)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("environment_id"), plan.EnvironmentId)...)
+	}
+}
+
+// keepPriorValue plans the value of an attribute in the state of an existing service, and reports whether there is one.
+func keepPriorValue(ctx context.Context, attributePath path.Path, prior types.String, planned *types.String, resp *resource.ModifyPlanResponse) bool {
+	if prior.ValueString() == "" {
+		return false
+	}
+	*planned = prior
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attributePath, prior)...)
+	return true
+}
+
+// hasEnvironments reports whether the services of a datacenter can be placed in an environment, which only public
+// datacenters support. A datacenter that is not known yet or cannot be looked up is taken to support it, the plan
+// validation reports why it could not be looked up.
+func (r *ServiceResource) hasEnvironments(ctx context.Context, datacenterId types.String) bool {
+	if r.APIClient == nil || !util.IsKnown(datacenterId) {
+		return true
+	}
+	datacenter, _ := r.getDatacenterForValidation(ctx, datacenterId.ValueString())
+	return datacenter == nil || datacenter.DatacenterType == datacenterTypeSolacePublic
+}
+
+// configNull reports whether a string attribute is left unset in the configuration.
+func configNull(ctx context.Context, config tfsdk.Config, attributePath path.Path, diagnostics *diag.Diagnostics) bool {
+	var value types.String
+	diagnostics.Append(config.GetAttribute(ctx, attributePath, &value)...)
+	return value.IsNull()
+}
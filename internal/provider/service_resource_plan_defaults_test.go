@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-solacecloud/internal/shared"
+	mc "terraform-provider-solacecloud/missioncontrol"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestModifyPlanForProviderDefaults(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&ServiceResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	configValue := func(values map[string]string) tftypes.Value {
+		attributes := map[string]tftypes.Value{}
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		for name, value := range values {
+			attributes[name] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(objectType, attributes)
+	}
+	environments := `{"data":[{"id":"env-prod","name":"Production"},{"id":"env-dev","name":"Development"}]}`
+
+	tests := []struct {
+		name                 string
+		resource             ServiceResource
+		config               map[string]string
+		creating             bool
+		state                ServiceResourceModel
+		expectedDatacenter   string
+		expectedServiceClass string
+		expectedEnvironment  string
+		expectedError        string
+	}{
+		{
+			name:                 "without provider defaults",
+			config:               map[string]string{"datacenter_id": "aws-ca-central-1a"},
+			creating:             true,
+			expectedDatacenter:   "aws-ca-central-1a",
+			expectedServiceClass: "DEVELOPER",
+		},
+		{
+			name:                 "inherited",
+			resource:             ServiceResource{DefaultDatacenterId: "eks-us-east-1", DefaultServiceClassId: "ENTERPRISE_250_STANDALONE", DefaultEnvironment: &shared.DefaultEnvironment{NameOrId: "Production"}},
+			creating:             true,
+			expectedDatacenter:   "eks-us-east-1",
+			expectedServiceClass: "ENTERPRISE_250_STANDALONE",
+			expectedEnvironment:  "env-prod",
+		},
+		{
+			name:                 "environment identifier",
+			resource:             ServiceResource{DefaultDatacenterId: "eks-us-east-1", DefaultEnvironment: &shared.DefaultEnvironment{NameOrId: "env-dev"}},
+			creating:             true,
+			expectedDatacenter:   "eks-us-east-1",
+			expectedServiceClass: "DEVELOPER",
+			expectedEnvironment:  "env-dev",
+		},
+		{
+			name:                 "configured values win",
+			resource:             ServiceResource{DefaultDatacenterId: "eks-us-east-1", DefaultServiceClassId: "ENTERPRISE_250_STANDALONE", DefaultEnvironment: &shared.DefaultEnvironment{NameOrId: "Production"}},
+			config:               map[string]string{"datacenter_id": "aws-ca-central-1a", "service_class_id": "DEVELOPER", "environment_id": "env-dev"},
+			creating:             true,
+			expectedDatacenter:   "aws-ca-central-1a",
+			expectedServiceClass: "DEVELOPER",
+			expectedEnvironment:  "env-dev",
+		},
+		{
+			name:                 "existing service keeps its values",
+			resource:             ServiceResource{DefaultDatacenterId: "eks-us-east-1", DefaultServiceClassId: "ENTERPRISE_250_STANDALONE", DefaultEnvironment: &shared.DefaultEnvironment{NameOrId: "Production"}},
+			state:                ServiceResourceModel{DatacenterId: types.StringValue("aws-ca-central-1a"), ServiceClassId: types.StringValue("DEVELOPER"), EnvironmentId: types.StringValue("env-dev")},
+			expectedDatacenter:   "aws-ca-central-1a",
+			expectedServiceClass: "DEVELOPER",
+			expectedEnvironment:  "env-dev",
+		},
+		{
+			name: "dedicated datacenter",
+			resource: ServiceResource{
+				APIClient:           NewRetryableClient(&stubDatacenterClient{datacenters: []mc.Datacenter{{Id: ptr("dedicated-1"), OperState: "up", Available: true, DatacenterType: "SolaceDedicated"}}}, shared.RetryPolicy{}),
+				DefaultDatacenterId: "dedicated-1",
+				DefaultEnvironment:  &shared.DefaultEnvironment{NameOrId: "Production"},
+			},
+			creating:             true,
+			expectedDatacenter:   "dedicated-1",
+			expectedServiceClass: "DEVELOPER",
+		},
+		{
+			name:          "unknown environment",
+			resource:      ServiceResource{DefaultDatacenterId: "eks-us-east-1", DefaultEnvironment: &shared.DefaultEnvironment{NameOrId: "Staging"}},
+			creating:      true,
+			expectedError: "Environment Not Found",
+		},
+		{
+			name:          "missing datacenter",
+			creating:      true,
+			expectedError: "Missing Datacenter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.resource
+			r.PlatformClient = &stubEnvironmentClient{body: environments}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: configValue(tt.config)}
+			plan := ServiceResourceModel{}
+			diags := config.Get(ctx, &plan)
+			if diags.HasError() {
+				t.Fatalf("could not read config: %v", diags)
+			}
+			resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan(config)}
+
+			r.modifyPlanForProviderDefaults(ctx, config, tt.creating, tt.state, &plan, resp)
+
+			if tt.expectedError != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.expectedError {
+					t.Fatalf("Expected error %q, got: %v", tt.expectedError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			var planned ServiceResourceModel
+			resp.Diagnostics.Append(resp.Plan.Get(ctx, &planned)...)
+			for _, planModel := range []ServiceResourceModel{plan, planned} {
+				if planModel.DatacenterId.ValueString() != tt.expectedDatacenter ||
+					planModel.ServiceClassId.ValueString() != tt.expectedServiceClass ||
+					planModel.EnvironmentId.ValueString() != tt.expectedEnvironment {
+					t.Errorf("Expected %s, %s, %s, got %s, %s, %s", tt.expectedDatacenter, tt.expectedServiceClass,
+						tt.expectedEnvironment, planModel.DatacenterId, planModel.ServiceClassId, planModel.EnvironmentId)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultEnvironmentIsResolvedOnce(t *testing.T) {
+	ctx := context.Background()
+	client := &stubEnvironmentClient{body: `{"data":[{"id":"env-prod","name":"Production"}]}`}
+	defaultEnvironment := &shared.DefaultEnvironment{NameOrId: "env-prod"}
+
+	for range 3 {
+		environmentId, diags := defaultEnvironment.Id(func(nameOrId string) (string, diag.Diagnostics) {
+			return lookupEnvironmentIdByNameOrId(ctx, client, nameOrId)
+		})
+		if diags.HasError() || environmentId != "env-prod" {
+			t.Fatalf("Expected env-prod, got %q: %v", environmentId, diags)
+		}
+	}
+	// The name search, then the identifier search.
+	if client.searches != 2 {
+		t.Errorf("Expected the environment to be resolved once with 2 searches, got %d", client.searches)
+	}
+}
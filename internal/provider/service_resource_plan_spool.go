@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-solacecloud/internal/model"
+	"terraform-provider-solacecloud/internal/util"
+	"terraform-provider-solacecloud/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// The spoolScaleUpCapabilityState values reported by a datacenter that prevent or delay scaling up a message spool.
+const (
+	spoolScaleUpNotSupported = "NOT SUPPORTED"
+	spoolScaleUpInProgress   = "INPROGRESS"
+)
+
+// validateMaxSpoolUsageChange checks a change of max_spool_usage on an existing service while planning, as
+// UpdateMessageSpool only reports problems once the update operation has been polled during the apply.
+func (r *ServiceResource) validateMaxSpoolUsageChange(ctx context.Context, state ServiceResourceModel, plan ServiceResourceModel) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if !util.IsKnown(state.MaxSpoolUsage) || !util.IsKnown(plan.MaxSpoolUsage) {
+		return diagnostics
+	}
+	current := state.MaxSpoolUsage.ValueInt64()
+	planned := plan.MaxSpoolUsage.ValueInt64()
+
+	if planned == current {
+		return diagnostics
+	}
+	if planned < current {
+		diagnostics.AddAttributeError(
+			path.Root("max_spool_usage"),
+			"Message Spool Cannot Shrink",
+			fmt.Sprintf("The message spool of an existing service can only grow. max_spool_usage cannot be decreased "+
+				"from %d GB to %d GB.", current, planned),
+		)
+		return diagnostics
+	}
+
+	billedGrowth := addSpoolBillingWarning(ctx, &diagnostics, state, planned)
+
+	if r.APIClient == nil {
+		return diagnostics
+	}
+
+	if util.IsKnown(state.DatacenterId) {
+		diagnostics.Append(r.validateSpoolScaleUpCapability(ctx, state.DatacenterId.ValueString())...)
+	}
+	if billedGrowth > 0 {
+		diagnostics.Append(r.validateSpoolExpansionLimit(ctx, state.ServiceClassId.ValueString(), billedGrowth)...)
+	}
+
+	return diagnostics
+}
+
+// addSpoolBillingWarning warns when the message spool expansion billed on top of the service class grows, and returns
+// by how many GB it grows. The size included with the service class comes from the message_spool details in state.
+func addSpoolBillingWarning(ctx context.Context, diagnostics *diag.Diagnostics, state ServiceResourceModel, planned int64) int64 {
+	if !util.IsKnown(state.MessageSpool) {
+		return 0
+	}
+
+	var spool model.MessageSpoolDetailsModel
+	if diags := state.MessageSpool.As(ctx, &spool, basetypes.ObjectAsOptions{}); diags.HasError() || !util.IsKnown(spool.DefaultGbSize) {
+		return 0
+	}
+
+	currentBilled := spool.ExpandedGbBilled.ValueInt64()
+	plannedBilled := max(planned-spool.DefaultGbSize.ValueInt64(), 0)
+	if plannedBilled <= currentBilled {
+		return 0
+	}
+
+	diagnostics.AddAttributeWarning(
+		path.Root("max_spool_usage"),
+		"Billed Message Spool Increase",
+		fmt.Sprintf("The service class includes %d GB of message spool. Increasing max_spool_usage to %d GB increases "+
+			"the billed message spool expansion from %d GB to %d GB.",
+			spool.DefaultGbSize.ValueInt64(), planned, currentBilled, plannedBilled),
+	)
+	return plannedBilled - currentBilled
+}
+
+func (r *ServiceResource) validateSpoolScaleUpCapability(ctx context.Context, datacenterId string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	apiClientResp, err := r.APIClient.GetDatacenterWithResponse(ctx, datacenterId)
+	if err != nil {
+		addValidationSkippedWarning(&diagnostics, path.Root("max_spool_usage"), err.Error())
+		return diagnostics
+	}
+	if apiClientResp.StatusCode() != http.StatusOK || apiClientResp.JSON200 == nil {
+		addValidationSkippedWarning(&diagnostics, path.Root("max_spool_usage"), fmt.Sprintf("received HTTP %d", apiClientResp.StatusCode()))
+		return diagnostics
+	}
+
+	capability := apiClientResp.JSON200.Data.SpoolScaleUpCapabilityInfo
+	if capability == nil || capability.SpoolScaleUpCapabilityState == nil {
+		return diagnostics
+	}
+
+	testMessage := ""
+	if capability.SpoolScaleUpTestMessage != nil && *capability.SpoolScaleUpTestMessage != "" {
+		testMessage = " " + *capability.SpoolScaleUpTestMessage
+	}
+
+	switch *capability.SpoolScaleUpCapabilityState {
+	case spoolScaleUpNotSupported:
+		diagnostics.AddAttributeError(
+			path.Root("max_spool_usage"),
+			"Message Spool Cannot Be Scaled Up",
+			fmt.Sprintf("Datacenter %q does not support scaling up the message spool of a service.%s", datacenterId, testMessage),
+		)
+	case spoolScaleUpInProgress:
+		diagnostics.AddAttributeWarning(
+			path.Root("max_spool_usage"),
+			"Message Spool Scale Up Not Confirmed",
+			fmt.Sprintf("Datacenter %q is still testing whether it supports scaling up the message spool of a service, "+
+				"the update may fail.%s", datacenterId, testMessage),
+		)
+	}
+	return diagnostics
+}
+
+// validateSpoolExpansionLimit compares the growth of the billed message spool expansion with what is left of the
+// organization's message spool expansion limit for the service class of the service. The limits are reported per
+// class, named by their name or type.
+func (r *ServiceResource) validateSpoolExpansionLimit(ctx context.Context, serviceClassId string, billedGrowth int64) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if r.OrganizationId == "" {
+		addValidationSkippedWarning(&diagnostics, path.Root("max_spool_usage"), "the organization is not known, set preflight = true")
+		return diagnostics
+	}
+
+	apiClientResp, err := r.APIClient.GetLimitsWithResponse(ctx, r.OrganizationId)
+	if err != nil {
+		addValidationSkippedWarning(&diagnostics, path.Root("max_spool_usage"), err.Error())
+		return diagnostics
+	}
+	if apiClientResp.StatusCode() != http.StatusOK || apiClientResp.JSON200 == nil {
+		addValidationSkippedWarning(&diagnostics, path.Root("max_spool_usage"), fmt.Sprintf("received HTTP %d", apiClientResp.StatusCode()))
+		return diagnostics
+	}
+
+	var limit *missioncontrol.MessageSpoolLimitUsage
+	limited := false
+	for i, usage := range apiClientResp.JSON200.Data {
+		if usage.Limit == nil {
+			continue
+		}
+		limited = true
+		if appliesToServiceClass(usage, serviceClassId) {
+			limit = &apiClientResp.JSON200.Data[i]
+			break
+		}
+	}
+	if !limited {
+		return diagnostics
+	}
+	if limit == nil {
+		addValidationSkippedWarning(&diagnostics, path.Root("max_spool_usage"),
+			fmt.Sprintf("no message spool expansion limit applies to the service class %q", serviceClassId))
+		return diagnostics
+	}
+
+	used := int64(0)
+	if limit.Used != nil {
+		used = int64(*limit.Used)
+	}
+	remaining := max(int64(*limit.Limit)-used, 0)
+	if billedGrowth > remaining {
+		diagnostics.AddAttributeError(
+			path.Root("max_spool_usage"),
+			"Message Spool Expansion Limit Exceeded",
+			fmt.Sprintf("This change needs %d GB of message spool expansion but only %d GB are left in your "+
+				"organization's limit for the service class %s. Request a higher limit from Solace or choose a smaller max_spool_usage.",
+				billedGrowth, remaining, serviceClassId),
+		)
+	}
+	return diagnostics
+}
+
+// appliesToServiceClass reports whether a message spool expansion limit is the one of a service class.
+func appliesToServiceClass(usage missioncontrol.MessageSpoolLimitUsage, serviceClassId string) bool {
+	return usage.Name != nil && strings.EqualFold(*usage.Name, serviceClassId) ||
+		usage.Type != nil && strings.EqualFold(*usage.Type, serviceClassId)
+}
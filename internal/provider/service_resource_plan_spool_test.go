@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"terraform-provider-solacecloud/internal/model"
+	"terraform-provider-solacecloud/internal/shared"
+	mc "terraform-provider-solacecloud/missioncontrol"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stubSpoolClient adds the organization's message spool expansion limits to the datacenter stub.
+type stubSpoolClient struct {
+	stubDatacenterClient
+	limits []mc.MessageSpoolLimitUsage
+}
+
+func (c *stubSpoolClient) GetLimitsWithResponse(_ context.Context, _ string, _ ...mc.RequestEditorFn) (*mc.GetLimitsResponse, error) {
+	return &mc.GetLimitsResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+		JSON200:      &mc.MessageSpoolLimitClassesResponse{Data: c.limits},
+	}, nil
+}
+
+func TestValidateMaxSpoolUsageChange(t *testing.T) {
+	messageSpool, diags := model.MessageSpoolDetailsModel{
+		DefaultGbSize:    types.Int64Value(50),
+		ExpandedGbBilled: types.Int64Value(0),
+		TotalGbSize:      types.Int64Value(50),
+	}.ToObjectValue()
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	state := ServiceResourceModel{
+		DatacenterId:   types.StringValue("aws-ca-central-1a"),
+		ServiceClassId: types.StringValue("ENTERPRISE_250_STANDALONE"),
+		MaxSpoolUsage:  types.Int64Value(50),
+		MessageSpool:   messageSpool,
+	}
+
+	datacenter := func(capabilityState string) []mc.Datacenter {
+		return []mc.Datacenter{{
+			Id:                         ptr("aws-ca-central-1a"),
+			SpoolScaleUpCapabilityInfo: &mc.SpoolScaleUpCapabilityInfo{SpoolScaleUpCapabilityState: ptr(capabilityState)},
+		}}
+	}
+
+	tests := []struct {
+		name             string
+		planned          int64
+		client           *stubSpoolClient
+		noOrganization   bool
+		expectedError    string
+		expectedWarnings []string
+	}{
+		{
+			name:    "unchanged",
+			planned: 50,
+		},
+		{
+			name:          "decrease",
+			planned:       40,
+			expectedError: "Message Spool Cannot Shrink",
+		},
+		{
+			name:             "increase within the limit",
+			planned:          70,
+			client:           &stubSpoolClient{stubDatacenterClient: stubDatacenterClient{datacenters: datacenter("SUPPORTED")}, limits: []mc.MessageSpoolLimitUsage{{Name: ptr("ENTERPRISE_250_STANDALONE"), Limit: ptr(int32(100)), Used: ptr(int32(60))}}},
+			expectedWarnings: []string{"Billed Message Spool Increase"},
+		},
+		{
+			name:             "increase beyond the limit",
+			planned:          100,
+			client:           &stubSpoolClient{stubDatacenterClient: stubDatacenterClient{datacenters: datacenter("SUPPORTED")}, limits: []mc.MessageSpoolLimitUsage{{Name: ptr("ENTERPRISE_250_STANDALONE"), Limit: ptr(int32(100)), Used: ptr(int32(60))}}},
+			expectedError:    "Message Spool Expansion Limit Exceeded",
+			expectedWarnings: []string{"Billed Message Spool Increase"},
+		},
+		{
+			name:    "only another service class has room",
+			planned: 70,
+			client: &stubSpoolClient{stubDatacenterClient: stubDatacenterClient{datacenters: datacenter("SUPPORTED")}, limits: []mc.MessageSpoolLimitUsage{
+				{Name: ptr("ENTERPRISE_1K_STANDALONE"), Limit: ptr(int32(100)), Used: ptr(int32(0))},
+				{Name: ptr("ENTERPRISE_250_STANDALONE"), Limit: ptr(int32(100)), Used: ptr(int32(100))},
+			}},
+			expectedError:    "Message Spool Expansion Limit Exceeded",
+			expectedWarnings: []string{"Billed Message Spool Increase"},
+		},
+		{
+			name:             "no limit for the service class",
+			planned:          70,
+			client:           &stubSpoolClient{stubDatacenterClient: stubDatacenterClient{datacenters: datacenter("SUPPORTED")}, limits: []mc.MessageSpoolLimitUsage{{Name: ptr("ENTERPRISE_1K_STANDALONE"), Limit: ptr(int32(100))}}},
+			expectedWarnings: []string{"Billed Message Spool Increase", "Could Not Validate Against Solace Cloud"},
+		},
+		{
+			name:             "organization not known",
+			planned:          70,
+			client:           &stubSpoolClient{stubDatacenterClient: stubDatacenterClient{datacenters: datacenter("SUPPORTED")}},
+			noOrganization:   true,
+			expectedWarnings: []string{"Billed Message Spool Increase", "Could Not Validate Against Solace Cloud"},
+		},
+		{
+			name:             "datacenter cannot scale up",
+			planned:          70,
+			client:           &stubSpoolClient{stubDatacenterClient: stubDatacenterClient{datacenters: datacenter("NOT SUPPORTED")}},
+			expectedError:    "Message Spool Cannot Be Scaled Up",
+			expectedWarnings: []string{"Billed Message Spool Increase"},
+		},
+		{
+			name:             "datacenter still testing scale up",
+			planned:          70,
+			client:           &stubSpoolClient{stubDatacenterClient: stubDatacenterClient{datacenters: datacenter("INPROGRESS")}},
+			expectedWarnings: []string{"Billed Message Spool Increase", "Message Spool Scale Up Not Confirmed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ServiceResource{OrganizationId: "myorg"}
+			if tt.noOrganization {
+				r.OrganizationId = ""
+			}
+			if tt.client != nil {
+				r.APIClient = NewRetryableClient(tt.client, shared.RetryPolicy{})
+			}
+			plan := state
+			plan.MaxSpoolUsage = types.Int64Value(tt.planned)
+
+			diags := r.validateMaxSpoolUsageChange(context.Background(), state, plan)
+
+			var errors []string
+			for _, d := range diags.Errors() {
+				errors = append(errors, d.Summary())
+			}
+			if tt.expectedError == "" && len(errors) > 0 || tt.expectedError != "" && (len(errors) != 1 || errors[0] != tt.expectedError) {
+				t.Errorf("expected error %q, got %v", tt.expectedError, errors)
+			}
+			var warnings []string
+			for _, d := range diags.Warnings() {
+				warnings = append(warnings, d.Summary())
+			}
+			if len(warnings) != len(tt.expectedWarnings) {
+				t.Fatalf("expected warnings %v, got %v", tt.expectedWarnings, warnings)
+			}
+			for i := range warnings {
+				if warnings[i] != tt.expectedWarnings[i] {
+					t.Errorf("expected warnings %v, got %v", tt.expectedWarnings, warnings)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-solacecloud/internal/shared"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestModifyPlanForImmutableChanges(t *testing.T) {
+	tests := []struct {
+		name                   string
+		providerReplace        bool
+		resourceReplace        types.Bool
+		locked                 bool
+		changeCustomRouterName bool
+		expectError            bool
+		expectedRequireReplace path.Paths
+	}{
+		{
+			name:            "replacement not enabled - should error",
+			resourceReplace: types.BoolNull(),
+			expectError:     true,
+		},
+		{
+			name:                   "enabled on the provider",
+			providerReplace:        true,
+			resourceReplace:        types.BoolNull(),
+			expectedRequireReplace: path.Paths{path.Root("datacenter_id")},
+		},
+		{
+			name:                   "enabled on the resource",
+			resourceReplace:        types.BoolValue(true),
+			expectedRequireReplace: path.Paths{path.Root("datacenter_id")},
+		},
+		{
+			// ImmutableStringPlanModifier reports the error, before ModifyPlan runs.
+			name:            "resource overrides provider",
+			providerReplace: true,
+			resourceReplace: types.BoolValue(false),
+		},
+		{
+			name:                   "resource overrides provider for custom_router_name",
+			providerReplace:        true,
+			resourceReplace:        types.BoolValue(false),
+			changeCustomRouterName: true,
+			expectError:            true,
+		},
+		{
+			name:            "locked service is protected",
+			providerReplace: true,
+			resourceReplace: types.BoolNull(),
+			locked:          true,
+			expectError:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ServiceResource{ReplaceOnImmutableChange: tt.providerReplace}
+
+			state := ServiceResourceModel{
+				DatacenterId:     types.StringValue("eks-us-east-1"),
+				ServiceClassId:   types.StringValue("DEVELOPER"),
+				CustomRouterName: types.StringNull(),
+				Locked:           types.BoolValue(tt.locked),
+			}
+			plan := state
+			plan.DatacenterId = types.StringValue("eks-us-west-1")
+			if tt.changeCustomRouterName {
+				plan.DatacenterId = state.DatacenterId
+				plan.CustomRouterName = types.StringValue("router")
+			}
+			plan.ReplaceOnImmutableChange = tt.resourceReplace
+
+			resp := &resource.ModifyPlanResponse{}
+			r.modifyPlanForImmutableChanges(state, plan, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError || len(resp.Diagnostics.Errors()) > 1 {
+				t.Errorf("Expected error: %v, got: %v", tt.expectError, resp.Diagnostics.Errors())
+			}
+			if len(resp.RequiresReplace) != len(tt.expectedRequireReplace) {
+				t.Fatalf("Expected RequiresReplace %v, got %v", tt.expectedRequireReplace, resp.RequiresReplace)
+			}
+			for i := range tt.expectedRequireReplace {
+				if !resp.RequiresReplace[i].Equal(tt.expectedRequireReplace[i]) {
+					t.Errorf("Expected RequiresReplace %v, got %v", tt.expectedRequireReplace, resp.RequiresReplace)
+				}
+			}
+		})
+	}
+}
+
+func TestImmutableAttributeChanges(t *testing.T) {
+	state := ServiceResourceModel{
+		DatacenterId:       types.StringValue("eks-us-east-1"),
+		MessageVpnName:     types.StringValue("vpn"),
+		EventBrokerVersion: types.StringValue("10.10.1.112-3"),
+		CustomRouterName:   types.StringValue("router"),
+	}
+	plan := state
+	plan.MessageVpnName = types.StringUnknown()
+	plan.EventBrokerVersion = types.StringValue("10.11.1.112-3")
+	plan.CustomRouterName = types.StringNull()
+
+	changes := immutableAttributeChanges(state, plan)
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d: %v", len(changes), changes)
+	}
+	if !changes[0].path.Equal(path.Root("event_broker_version")) {
+		t.Errorf("Expected event_broker_version to change, got %s", changes[0].path)
+	}
+	if !changes[1].path.Equal(path.Root("custom_router_name")) {
+		t.Errorf("Expected custom_router_name to change, got %s", changes[1].path)
+	}
+}
+
+func TestModifyPlanForServiceState(t *testing.T) {
+	tests := []struct {
+		name                   string
+		state                  ServiceResourceModel
+		expectedRequireReplace path.Paths
+		expectedWarning        string
+	}{
+		{
+			name:  "healthy service",
+			state: ServiceResourceModel{CreationState: types.StringValue("COMPLETED"), AdminState: types.StringValue("START")},
+		},
+		{
+			name: "creation failed",
+			state: ServiceResourceModel{CreationState: types.StringValue("FAILED"), AdminState: types.StringValue("START"),
+				ErrorId: types.StringValue("error-id"), ErrorMessage: types.StringValue("no capacity")},
+			expectedRequireReplace: path.Paths{path.Root("creation_state")},
+			expectedWarning:        "Service Will Be Replaced",
+		},
+		{
+			name:                   "being destroyed",
+			state:                  ServiceResourceModel{CreationState: types.StringValue("COMPLETED"), AdminState: types.StringValue("DESTROY")},
+			expectedRequireReplace: path.Paths{path.Root("admin_state")},
+			expectedWarning:        "Service Will Be Replaced",
+		},
+		{
+			name: "locked and being destroyed",
+			state: ServiceResourceModel{CreationState: types.StringValue("COMPLETED"), AdminState: types.StringValue("DESTROY"),
+				Locked: types.BoolValue(true)},
+			expectedWarning: "Locked Service Not Replaced",
+		},
+		{
+			name:            "stopped",
+			state:           ServiceResourceModel{CreationState: types.StringValue("COMPLETED"), AdminState: types.StringValue("STOP")},
+			expectedWarning: "Service Is Stopped",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			plan, _ := plannedService(t, &ServiceResource{}, false)
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+
+			modifyPlanForServiceState(ctx, tt.state, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if len(resp.RequiresReplace) != len(tt.expectedRequireReplace) ||
+				(len(resp.RequiresReplace) > 0 && !resp.RequiresReplace[0].Equal(tt.expectedRequireReplace[0])) {
+				t.Errorf("expected RequiresReplace %v, got %v", tt.expectedRequireReplace, resp.RequiresReplace)
+			}
+			if tt.expectedWarning == "" {
+				if resp.Diagnostics.WarningsCount() != 0 {
+					t.Errorf("unexpected warnings: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != tt.expectedWarning {
+				t.Fatalf("expected warning %q, got %v", tt.expectedWarning, resp.Diagnostics)
+			}
+			for _, p := range tt.expectedRequireReplace {
+				var value types.String
+				resp.Plan.GetAttribute(ctx, p, &value)
+				if !value.IsUnknown() {
+					t.Errorf("expected %s to be planned as unknown, got %s", p, value)
+				}
+			}
+		})
+	}
+}
+
+func TestModifyPlanForCredentialStorage(t *testing.T) {
+	tests := []struct {
+		name          string
+		state         types.Bool
+		plan          types.Bool
+		expectUnknown bool
+	}{
+		{name: "default", state: types.BoolNull(), plan: types.BoolNull()},
+		{name: "explicitly stored", state: types.BoolNull(), plan: types.BoolValue(true)},
+		{name: "no longer stored", state: types.BoolNull(), plan: types.BoolValue(false), expectUnknown: true},
+		{name: "stored again", state: types.BoolValue(false), plan: types.BoolNull(), expectUnknown: true},
+		{name: "still not stored", state: types.BoolValue(false), plan: types.BoolValue(false)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			plan, _ := plannedService(t, &ServiceResource{}, false)
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+
+			modifyPlanForCredentialStorage(ctx, ServiceResourceModel{StoreCredentials: tt.state},
+				ServiceResourceModel{StoreCredentials: tt.plan}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			for _, p := range []string{"message_vpn", "message_vpns", "dmr_cluster"} {
+				var value attr.Value
+				resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(p), &value)...)
+				if value.IsUnknown() != tt.expectUnknown {
+					t.Errorf("expected %s unknown to be %v, got %s", p, tt.expectUnknown, value)
+				}
+			}
+		})
+	}
+}
+
+func TestModifyPlanPermissions(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&ServiceResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	nullValues := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		nullValues[name] = tftypes.NewValue(attributeType, nil)
+	}
+	service := tftypes.NewValue(objectType, nullValues)
+	none := tftypes.NewValue(objectType, nil)
+
+	viewer := shared.Permissions{"services:view": true}
+	tests := []struct {
+		name        string
+		permissions shared.Permissions
+		plan        tftypes.Value
+		state       tftypes.Value
+		expectError bool
+	}{
+		{name: "create without permission", permissions: viewer, plan: service, state: none, expectError: true},
+		{name: "delete without permission", permissions: viewer, plan: none, state: service, expectError: true},
+		{name: "delete", permissions: shared.Permissions{"services:view": true, "services:delete": true}, plan: none, state: service},
+		{name: "delete without preflight", plan: none, state: service},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ServiceResource{Permissions: tt.permissions}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tt.plan}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: tfsdk.State{Schema: schemaResp.Schema, Raw: tt.state}}, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Fatalf("Expected error: %v, got: %v", tt.expectError, resp.Diagnostics.Errors())
+			}
+			if tt.expectError && resp.Diagnostics.Errors()[0].Summary() != "Missing API Token Permissions" {
+				t.Errorf("Expected a missing permissions error, got: %v", resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
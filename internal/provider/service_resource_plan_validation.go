@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"terraform-provider-solacecloud/internal/util"
+	"terraform-provider-solacecloud/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	datacenterOperStateUp      = "up"
+	datacenterTypeSolacePublic = "SolacePublic"
+)
+
+// validatePlanAgainstMissionControl checks the placement of a service that is about to be created against what Mission
+// Control offers, so that a typo fails the plan instead of an apply that may have already created other resources.
+// Problems reaching the API only produce warnings: the checks are a convenience, the apply still validates everything.
+func (r *ServiceResource) validatePlanAgainstMissionControl(ctx context.Context, plan ServiceResourceModel) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if r.APIClient == nil {
+		return diagnostics
+	}
+
+	var serviceClassDiags diag.Diagnostics
+	if util.IsKnown(plan.ServiceClassId) {
+		serviceClassDiags = r.validateServiceClass(ctx, plan.ServiceClassId.ValueString())
+		diagnostics.Append(serviceClassDiags...)
+	}
+
+	if !util.IsKnown(plan.DatacenterId) {
+		return diagnostics
+	}
+	datacenterId := plan.DatacenterId.ValueString()
+
+	datacenter, diags := r.getDatacenterForValidation(ctx, datacenterId)
+	diagnostics.Append(diags...)
+	if datacenter == nil {
+		return diagnostics
+	}
+
+	if datacenter.OperState != datacenterOperStateUp || !datacenter.Available {
+		diagnostics.AddAttributeError(
+			path.Root("datacenter_id"),
+			"Datacenter Not Available",
+			fmt.Sprintf("Datacenter %q cannot host new services: its operational state is %q and available is %t.",
+				datacenterId, datacenter.OperState, datacenter.Available),
+		)
+	}
+
+	serviceClassId := plan.ServiceClassId.ValueString()
+	if util.IsKnown(plan.ServiceClassId) && !serviceClassDiags.HasError() && datacenter.SupportedServiceClasses != nil {
+		supported := serviceClassIdStrings(*datacenter.SupportedServiceClasses)
+		if !slices.Contains(supported, serviceClassId) {
+			diagnostics.AddAttributeError(
+				path.Root("service_class_id"),
+				"Service Class Not Supported",
+				fmt.Sprintf("Datacenter %q does not support the service class %q.%s Supported service classes: %s.",
+					datacenterId, serviceClassId, util.DidYouMean(serviceClassId, supported), strings.Join(supported, ", ")),
+			)
+		}
+	}
+
+	if util.IsKnown(plan.EnvironmentId) && datacenter.DatacenterType != datacenterTypeSolacePublic {
+		diagnostics.AddAttributeError(
+			path.Root("environment_id"),
+			"Environment Not Supported",
+			fmt.Sprintf("Datacenter %q is a %s datacenter. You can only specify an environment identifier when "+
+				"creating services in a Public Region (SolacePublic), remove environment_id or choose a public datacenter.",
+				datacenterId, datacenter.DatacenterType),
+		)
+	}
+
+	if util.IsKnown(plan.EventBrokerVersion) {
+		diagnostics.Append(r.validateEventBrokerVersion(ctx, datacenterId, plan.EventBrokerVersion.ValueString(), plan.ServiceClassId.ValueString())...)
+	}
+
+	return diagnostics
+}
+
+// getDatacenterForValidation returns the datacenter, or nil when it does not exist or could not be retrieved.
+func (r *ServiceResource) getDatacenterForValidation(ctx context.Context, datacenterId string) (*missioncontrol.Datacenter, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	apiClientResp, err := r.APIClient.GetDatacenterWithResponse(ctx, datacenterId)
+	if err != nil {
+		addValidationSkippedWarning(&diagnostics, path.Root("datacenter_id"), err.Error())
+		return nil, diagnostics
+	}
+
+	switch {
+	case apiClientResp.StatusCode() == http.StatusOK && apiClientResp.JSON200 != nil:
+		return &apiClientResp.JSON200.Data, diagnostics
+	case apiClientResp.StatusCode() == http.StatusNotFound:
+		diagnostics.AddAttributeError(
+			path.Root("datacenter_id"),
+			"Unknown Datacenter",
+			fmt.Sprintf("Datacenter %q does not exist or is not available to your organization.%s",
+				datacenterId, util.DidYouMean(datacenterId, r.datacenterIds(ctx))),
+		)
+	default:
+		addValidationSkippedWarning(&diagnostics, path.Root("datacenter_id"), fmt.Sprintf("received HTTP %d", apiClientResp.StatusCode()))
+	}
+	return nil, diagnostics
+}
+
+// datacenterIds lists the datacenters available to the organization, for suggestions only.
+func (r *ServiceResource) datacenterIds(ctx context.Context) []string {
+	apiClientResp, err := r.APIClient.GetDatacentersWithResponse(ctx, &missioncontrol.GetDatacentersParams{})
+	if err != nil || apiClientResp.JSON200 == nil {
+		return nil
+	}
+
+	var ids []string
+	for _, datacenter := range apiClientResp.JSON200.Data {
+		if datacenter.Id != nil {
+			ids = append(ids, *datacenter.Id)
+		}
+	}
+	return ids
+}
+
+func (r *ServiceResource) validateEventBrokerVersion(ctx context.Context, datacenterId string, version string, serviceClassId string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	apiClientResp, err := r.APIClient.GetEventBrokerServiceVersionsWithResponse(ctx, datacenterId)
+	if err != nil {
+		addValidationSkippedWarning(&diagnostics, path.Root("event_broker_version"), err.Error())
+		return diagnostics
+	}
+	if apiClientResp.StatusCode() != http.StatusOK || apiClientResp.JSON200 == nil {
+		addValidationSkippedWarning(&diagnostics, path.Root("event_broker_version"), fmt.Sprintf("received HTTP %d", apiClientResp.StatusCode()))
+		return diagnostics
+	}
+
+	var offered []string
+	for _, brokerVersion := range apiClientResp.JSON200.Data {
+		offered = append(offered, brokerVersion.Version)
+		if !eventBrokerVersionMatches(version, brokerVersion) {
+			continue
+		}
+		supported := serviceClassIdStrings(brokerVersion.SupportedServiceClasses)
+		if serviceClassId != "" && len(supported) > 0 && !slices.Contains(supported, serviceClassId) {
+			diagnostics.AddAttributeError(
+				path.Root("event_broker_version"),
+				"Event Broker Version Not Supported",
+				fmt.Sprintf("Event broker version %q does not support the service class %q. Supported service classes: %s.",
+					version, serviceClassId, strings.Join(supported, ", ")),
+			)
+		}
+		return diagnostics
+	}
+
+	diagnostics.AddAttributeError(
+		path.Root("event_broker_version"),
+		"Event Broker Version Not Offered",
+		fmt.Sprintf("Event broker version %q is not offered in datacenter %q.%s Offered versions: %s.",
+			version, datacenterId, util.DidYouMean(version, offered), strings.Join(offered, ", ")),
+	)
+	return diagnostics
+}
+
+// eventBrokerVersionMatches compares a configured version, major.minor.load.build-cloudRevision, with an offered one,
+// which may or may not include the cloud revision.
+func eventBrokerVersionMatches(version string, brokerVersion missioncontrol.EventBrokerServiceVersion) bool {
+	if version == brokerVersion.Version || version == brokerVersion.ContainerImageTag {
+		return true
+	}
+	base, _, _ := strings.Cut(version, "-")
+	return base == brokerVersion.Version
+}
+
+func serviceClassIdStrings(serviceClassIds []missioncontrol.ServiceClassId) []string {
+	var ids []string
+	for _, id := range serviceClassIds {
+		ids = append(ids, string(id))
+	}
+	return ids
+}
+
+func addValidationSkippedWarning(diagnostics *diag.Diagnostics, attributePath path.Path, reason string) {
+	diagnostics.AddAttributeWarning(
+		attributePath,
+		"Could Not Validate Against Solace Cloud",
+		fmt.Sprintf("The value could not be checked against Solace Cloud while planning (%s). It will be validated "+
+			"when the service is created.", reason),
+	)
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"terraform-provider-solacecloud/internal/shared"
+	mc "terraform-provider-solacecloud/missioncontrol"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stubDatacenterClient answers the calls made by the plan time validation.
+type stubDatacenterClient struct {
+	CRUDClientWithResponses
+	datacenters    []mc.Datacenter
+	versions       []mc.EventBrokerServiceVersion
+	serviceClasses []mc.ServiceClassId
+	err            error
+	classCalls     int
+}
+
+func (c *stubDatacenterClient) GetDatacenterWithResponse(_ context.Context, id string, _ ...mc.RequestEditorFn) (*mc.GetDatacenterResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	for _, datacenter := range c.datacenters {
+		if *datacenter.Id == id {
+			return &mc.GetDatacenterResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200:      &mc.DatacenterResponse{Data: datacenter},
+			}, nil
+		}
+	}
+	return &mc.GetDatacenterResponse{HTTPResponse: &http.Response{StatusCode: http.StatusNotFound}}, nil
+}
+
+func (c *stubDatacenterClient) GetDatacentersWithResponse(_ context.Context, _ *mc.GetDatacentersParams, _ ...mc.RequestEditorFn) (*mc.GetDatacentersResponse, error) {
+	return &mc.GetDatacentersResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+		JSON200:      &mc.DatacentersResponse{Data: c.datacenters},
+	}, nil
+}
+
+func (c *stubDatacenterClient) GetEventBrokerServiceVersionsWithResponse(_ context.Context, _ string, _ ...mc.RequestEditorFn) (*mc.GetEventBrokerServiceVersionsResponse, error) {
+	return &mc.GetEventBrokerServiceVersionsResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+		JSON200:      &mc.EventBrokerServiceVersionsResponse{Data: c.versions},
+	}, nil
+}
+
+func (c *stubDatacenterClient) GetServiceClassesWithResponse(_ context.Context, _ *mc.GetServiceClassesParams, _ ...mc.RequestEditorFn) (*mc.GetServiceClassesResponse, error) {
+	c.classCalls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	var serviceClasses []mc.ServiceClass
+	for _, id := range c.serviceClasses {
+		serviceClasses = append(serviceClasses, mc.ServiceClass{Id: ptr(id)})
+	}
+	return &mc.GetServiceClassesResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+		JSON200:      &mc.ServiceClassesResponse{Data: serviceClasses},
+	}, nil
+}
+
+func TestValidatePlanAgainstMissionControl(t *testing.T) {
+	client := &stubDatacenterClient{
+		datacenters: []mc.Datacenter{
+			{
+				Id:                      ptr("aws-ca-central-1a"),
+				OperState:               "up",
+				Available:               true,
+				DatacenterType:          "SolacePublic",
+				SupportedServiceClasses: &[]mc.ServiceClassId{mc.ServiceClassIdDEVELOPER, mc.ServiceClassIdENTERPRISE1KSTANDALONE},
+			},
+			{Id: ptr("aws-down"), OperState: "down", Available: true, DatacenterType: "SolacePublic"},
+			{Id: ptr("dedicated-1"), OperState: "up", Available: true, DatacenterType: "SolaceDedicated"},
+		},
+		versions: []mc.EventBrokerServiceVersion{
+			{Version: "10.10.1.112", SupportedServiceClasses: []mc.ServiceClassId{mc.ServiceClassIdENTERPRISE1KSTANDALONE}},
+			{Version: "10.11.0.34"},
+		},
+		serviceClasses: []mc.ServiceClassId{mc.ServiceClassIdDEVELOPER, mc.ServiceClassIdENTERPRISE1KSTANDALONE, mc.ServiceClassIdENTERPRISE1KHIGHAVAILABILITY},
+	}
+
+	tests := []struct {
+		name            string
+		plan            ServiceResourceModel
+		client          *stubDatacenterClient
+		expectedSummary string
+		expectedDetail  string
+		expectWarning   bool
+	}{
+		{
+			name: "valid",
+			plan: ServiceResourceModel{DatacenterId: types.StringValue("aws-ca-central-1a"), ServiceClassId: types.StringValue("DEVELOPER"),
+				EventBrokerVersion: types.StringValue("10.11.0.34-1"), EnvironmentId: types.StringValue("env")},
+		},
+		{
+			name:            "unknown datacenter",
+			plan:            ServiceResourceModel{DatacenterId: types.StringValue("aws-ca-centrl-1a")},
+			expectedSummary: "Unknown Datacenter",
+			expectedDetail:  `Did you mean "aws-ca-central-1a"?`,
+		},
+		{
+			name:            "datacenter down",
+			plan:            ServiceResourceModel{DatacenterId: types.StringValue("aws-down")},
+			expectedSummary: "Datacenter Not Available",
+		},
+		{
+			name:            "service class not offered",
+			plan:            ServiceResourceModel{DatacenterId: types.StringValue("aws-ca-central-1a"), ServiceClassId: types.StringValue("DEVELOPPER")},
+			expectedSummary: "Service Class Not Offered",
+			expectedDetail:  `Did you mean "DEVELOPER"?`,
+		},
+		{
+			name:            "unsupported service class",
+			plan:            ServiceResourceModel{DatacenterId: types.StringValue("aws-ca-central-1a"), ServiceClassId: types.StringValue("ENTERPRISE_1K_HIGHAVAILABILITY")},
+			expectedSummary: "Service Class Not Supported",
+			expectedDetail:  "Supported service classes: DEVELOPER, ENTERPRISE_1K_STANDALONE.",
+		},
+		{
+			name:            "environment in a dedicated datacenter",
+			plan:            ServiceResourceModel{DatacenterId: types.StringValue("dedicated-1"), EnvironmentId: types.StringValue("env")},
+			expectedSummary: "Environment Not Supported",
+		},
+		{
+			name:            "version not offered",
+			plan:            ServiceResourceModel{DatacenterId: types.StringValue("aws-ca-central-1a"), EventBrokerVersion: types.StringValue("10.11.0.43-1")},
+			expectedSummary: "Event Broker Version Not Offered",
+			expectedDetail:  `Did you mean "10.11.0.34"?`,
+		},
+		{
+			name: "version does not support the service class",
+			plan: ServiceResourceModel{DatacenterId: types.StringValue("aws-ca-central-1a"), ServiceClassId: types.StringValue("DEVELOPER"),
+				EventBrokerVersion: types.StringValue("10.10.1.112-3")},
+			expectedSummary: "Event Broker Version Not Supported",
+		},
+		{
+			name:          "api not reachable",
+			plan:          ServiceResourceModel{DatacenterId: types.StringValue("aws-ca-central-1a")},
+			client:        &stubDatacenterClient{err: errors.New("connection refused")},
+			expectWarning: true,
+		},
+		{
+			name: "unknown datacenter is not checked",
+			plan: ServiceResourceModel{DatacenterId: types.StringUnknown()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := client
+			if tt.client != nil {
+				stub = tt.client
+			}
+			r := &ServiceResource{APIClient: NewRetryableClient(stub, shared.RetryPolicy{})}
+
+			diags := r.validatePlanAgainstMissionControl(context.Background(), tt.plan)
+
+			if tt.expectWarning != (diags.WarningsCount() > 0) {
+				t.Errorf("unexpected warnings: %v", diags.Warnings())
+			}
+			if tt.expectedSummary == "" {
+				if diags.HasError() {
+					t.Errorf("unexpected errors: %v", diags.Errors())
+				}
+				return
+			}
+			if len(diags.Errors()) != 1 {
+				t.Fatalf("expected a single error, got: %v", diags.Errors())
+			}
+			if err := diags.Errors()[0]; err.Summary() != tt.expectedSummary || !strings.Contains(err.Detail(), tt.expectedDetail) {
+				t.Errorf("expected %q with %q, got %q: %s", tt.expectedSummary, tt.expectedDetail, err.Summary(), err.Detail())
+			}
+			if _, ok := diags.Errors()[0].(diag.DiagnosticWithPath); !ok {
+				t.Errorf("expected an attribute error")
+			}
+		})
+	}
+}
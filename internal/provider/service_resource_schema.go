@@ -5,17 +5,18 @@ import (
 	"regexp"
 	"strings"
 	"terraform-provider-solacecloud/internal/model"
+	"terraform-provider-solacecloud/internal/shared"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -30,9 +31,17 @@ func NewServiceResource() resource.Resource {
 
 // ServiceResource defines the resource implementation.
 type ServiceResource struct {
-	APIClient          *RetryableClientWithResponses
-	APIPollingInterval int
-	APIToken           string
+	APIClient                *RetryableClientWithResponses
+	APIPollingInterval       int
+	APIToken                 string
+	DefaultDatacenterId      string
+	DefaultEnvironment       *shared.DefaultEnvironment
+	DefaultServiceClassId    string
+	OrganizationId           string
+	Permissions              shared.Permissions
+	PlatformClient           EnvironmentSearchClient
+	ReplaceOnImmutableChange bool
+	ServiceClasses           *shared.ServiceClassCache
 }
 
 type ServiceResourceModel struct {
@@ -51,7 +60,29 @@ type ServiceResourceModel struct {
 	CustomRouterName    types.String          `tfsdk:"custom_router_name"`
 	EnvironmentId       types.String          `tfsdk:"environment_id"`
 	MessageVpn          basetypes.ObjectValue `tfsdk:"message_vpn"`
+	MessageVpns         types.List            `tfsdk:"message_vpns"`
 	DmrClusterInfo      basetypes.ObjectValue `tfsdk:"dmr_cluster"`
+	MessageSpool        basetypes.ObjectValue `tfsdk:"message_spool"`
+	Infrastructure      basetypes.ObjectValue `tfsdk:"infrastructure"`
+
+	AdminState                types.String `tfsdk:"admin_state"`
+	CreationState             types.String `tfsdk:"creation_state"`
+	ErrorId                   types.String `tfsdk:"error_id"`
+	ErrorMessage              types.String `tfsdk:"error_message"`
+	CreatedBy                 types.String `tfsdk:"created_by"`
+	CreatedTime               types.String `tfsdk:"created_time"`
+	UpdatedBy                 types.String `tfsdk:"updated_by"`
+	UpdatedTime               types.String `tfsdk:"updated_time"`
+	AllowedActions            types.List   `tfsdk:"allowed_actions"`
+	EventMeshId               types.String `tfsdk:"event_mesh_id"`
+	InfrastructureId          types.String `tfsdk:"infrastructure_id"`
+	DefaultManagementHostname types.String `tfsdk:"default_management_hostname"`
+
+	ReplaceOnImmutableChange types.Bool   `tfsdk:"replace_on_immutable_change"`
+	AdoptExisting            types.Bool   `tfsdk:"adopt_existing"`
+	OnCreateFailure          types.String `tfsdk:"on_create_failure"`
+	CreateRetries            types.Int64  `tfsdk:"create_retries"`
+	StoreCredentials         types.Bool   `tfsdk:"store_credentials"`
 }
 
 type NameNotDefaultValidator struct{}
@@ -74,6 +105,18 @@ func (v NameNotDefaultValidator) ValidateString(ctx context.Context, req validat
 	}
 }
 
+// defaultServiceClassId is the service class of the services that set none, unless the provider sets another.
+const defaultServiceClassId = "DEVELOPER"
+
+// serviceClassIdValidator checks the form of a service class identifier, which service_class_id and the
+// default_service_class_id of the provider share.
+func serviceClassIdValidator() validator.String {
+	return stringvalidator.RegexMatches(
+		regexp.MustCompile(`^[A-Z0-9_]+$`),
+		"must be a service class identifier such as DEVELOPER or ENTERPRISE_1K_STANDALONE",
+	)
+}
+
 func (r *ServiceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_service"
 }
@@ -141,39 +184,25 @@ func (r *ServiceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"service_class_id": schema.StringAttribute{
-				MarkdownDescription: "The identifier of the service class.",
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString("DEVELOPER"),
-				Validators: []validator.String{
-					stringvalidator.OneOf(
-						"DEVELOPER",
-						"ENTERPRISE_250_HIGHAVAILABILITY",
-						"ENTERPRISE_1K_HIGHAVAILABILITY",
-						"ENTERPRISE_50K_HIGHAVAILABILITY",
-						"ENTERPRISE_100K_HIGHAVAILABILITY",
-						"ENTERPRISE_5K_HIGHAVAILABILITY",
-						"ENTERPRISE_10K_HIGHAVAILABILITY",
-						"ENTERPRISE_250_STANDALONE",
-						"ENTERPRISE_1K_STANDALONE",
-						"ENTERPRISE_5K_STANDALONE",
-						"ENTERPRISE_10K_STANDALONE",
-						"ENTERPRISE_50K_STANDALONE",
-						"ENTERPRISE_100K_STANDALONE",
-					),
-				},
+				MarkdownDescription: "The identifier of the service class. The service classes offered by Solace Cloud are checked while planning. " +
+					"Defaults to the default_service_class_id of the provider, DEVELOPER unless it is set.",
+				Optional:   true,
+				Computed:   true,
+				Validators: []validator.String{serviceClassIdValidator()},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 					NewImmutableStringPlanModifier(),
 				},
 			},
 			"datacenter_id": schema.StringAttribute{
-				MarkdownDescription: "The identifier of the datacenter.",
-				Required:            true,
+				MarkdownDescription: "The identifier of the datacenter. Defaults to the default_datacenter_id of the provider, one of them must be set.",
+				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 50),
 				},
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					NewImmutableStringPlanModifier(),
 				},
 			},
@@ -239,7 +268,7 @@ func (r *ServiceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					"You can only specify an environment identifier when creating services in a Public Region. " +
 					"You cannot specify an environment identifier when creating a service in a Dedicated Region." +
 					"Creating a service in a Public Region without specifying an environment identifier places it" +
-					"in the default environment.",
+					"in the default environment. Defaults to the default_environment of the provider when it is set and the datacenter is in a Public Region.",
 				Optional: true,
 				Computed: true,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown(),
@@ -253,8 +282,116 @@ func (r *ServiceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"message_vpn": model.MessageVpnAttributeSchema(),
-			"dmr_cluster": model.DmrClusterInfoAttributeSchema(),
+			"message_vpn":    model.MessageVpnAttributeSchema(),
+			"message_vpns":   model.MessageVpnListAttributeSchema(),
+			"dmr_cluster":    model.DmrClusterInfoAttributeSchema(),
+			"message_spool":  model.MessageSpoolDetailsAttributeSchema(),
+			"infrastructure": model.InfrastructureDetailsAttributeSchema(),
+			"admin_state": schema.StringAttribute{
+				MarkdownDescription: "The administration state of the event broker service: INITIAL, START, STOP or DESTROY.",
+				Computed:            true,
+			},
+			"creation_state": schema.StringAttribute{
+				MarkdownDescription: "The creation state of the event broker service: PENDING, INPROGRESS, COMPLETED or FAILED.",
+				Computed:            true,
+			},
+			"error_id": schema.StringAttribute{
+				MarkdownDescription: "The error ID if the event broker service creation failed.",
+				Computed:            true,
+			},
+			"error_message": schema.StringAttribute{
+				MarkdownDescription: "The error message if the event broker service creation failed.",
+				Computed:            true,
+			},
+			"created_by": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier representing the user who created the event broker service.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_time": schema.StringAttribute{
+				MarkdownDescription: "The time the event broker service was created, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_by": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier representing the user who last updated the event broker service.",
+				Computed:            true,
+			},
+			"updated_time": schema.StringAttribute{
+				MarkdownDescription: "The time of the last update performed on the event broker service, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"allowed_actions": schema.ListAttribute{
+				MarkdownDescription: "The actions the API token's user can perform on the event broker service, such as " +
+					"get, configure, update, broker_update, delete and assign.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"event_mesh_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the event mesh the event broker service belongs to, if any.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"infrastructure_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the infrastructure of the event broker service.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_management_hostname": schema.StringAttribute{
+				MarkdownDescription: "The default management hostname of the event broker service.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"replace_on_immutable_change": schema.BoolAttribute{
+				MarkdownDescription: "When true, changing an attribute that cannot be updated in place (datacenter_id, " +
+					"service_class_id, message_vpn_name, cluster_name, environment_id, event_broker_version or " +
+					"custom_router_name) replaces the service instead of failing the plan. Locked services are never " +
+					"replaced. Defaults to the provider's replace_on_immutable_change setting. Intended for development " +
+					"and test services, as replacing a service deletes it along with its configuration.",
+				Optional: true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "When true and creating the service fails because a service with the same name " +
+					"already exists in the environment, for example after an interrupted apply, that service is adopted " +
+					"into the Terraform state instead of failing. The service is only adopted when its datacenter_id, " +
+					"service_class_id, message_vpn_name, cluster_name, environment_id, event_broker_version and " +
+					"custom_router_name match the configuration.",
+				Optional: true,
+			},
+			"on_create_failure": schema.StringAttribute{
+				MarkdownDescription: "What to do with a service whose creation FAILED: `keep` leaves it in place and marks " +
+					"it as tainted so the next apply replaces it, `delete` deletes it and waits for the deletion to " +
+					"complete, retrying creation up to create_retries times. Defaults to `keep`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(onCreateFailureKeep, onCreateFailureDelete),
+				},
+			},
+			"create_retries": schema.Int64Attribute{
+				MarkdownDescription: "The number of times to retry creating the service after a failed service has been " +
+					"deleted. Only used when on_create_failure is `delete`. Defaults to 0.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+					int64validator.AlsoRequires(path.MatchRoot("on_create_failure")),
+				},
+			},
+			"store_credentials": schema.BoolAttribute{
+				MarkdownDescription: "When false, the management, messaging client and DMR cluster passwords are left " +
+					"out of the Terraform state and the password attributes are null. Use the " +
+					"solacecloud_service_credentials ephemeral resource to fetch them when needed. Defaults to true.",
+				Optional: true,
+			},
 		},
 	}
 }
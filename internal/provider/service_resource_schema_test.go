@@ -102,6 +102,25 @@ func TestCorrectFieldsHaveSensitive(t *testing.T) {
 	}
 }
 
+func TestLifecycleFieldsAreNotCopiedFromState(t *testing.T) {
+	serviceResource := provider.NewServiceResource()
+	schemaResp := &resource.SchemaResponse{}
+	serviceResource.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+
+	// These change on the server, so their planned value must be unknown whenever the service changes.
+	for _, fieldName := range []string{"admin_state", "creation_state", "error_id", "error_message"} {
+		t.Run(fieldName, func(t *testing.T) {
+			stringAttr, ok := schemaResp.Schema.Attributes[fieldName].(schema.StringAttribute)
+			if !ok {
+				t.Fatalf("Field %s is not a StringAttribute", fieldName)
+			}
+			if len(stringAttr.PlanModifiers) > 0 {
+				t.Errorf("Field %s has plan modifiers %v", fieldName, stringAttr.PlanModifiers)
+			}
+		})
+	}
+}
+
 /*
 *
 Function that searches for multiple attributes and returns a map of found attributes
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"terraform-provider-solacecloud/internal/util"
+	"terraform-provider-solacecloud/missioncontrol"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// knownServiceClassIds are the service classes this provider version was released with. They are only used when the
+// service classes cannot be listed from Solace Cloud, the list from the API is authoritative.
+var knownServiceClassIds = []string{
+	string(missioncontrol.ServiceClassIdDEVELOPER),
+	string(missioncontrol.ServiceClassIdENTERPRISE250HIGHAVAILABILITY),
+	string(missioncontrol.ServiceClassIdENTERPRISE1KHIGHAVAILABILITY),
+	string(missioncontrol.ServiceClassIdENTERPRISE5KHIGHAVAILABILITY),
+	string(missioncontrol.ServiceClassIdENTERPRISE10KHIGHAVAILABILITY),
+	string(missioncontrol.ServiceClassIdENTERPRISE50KHIGHAVAILABILITY),
+	string(missioncontrol.ServiceClassIdENTERPRISE100KHIGHAVAILABILITY),
+	string(missioncontrol.ServiceClassIdENTERPRISE250STANDALONE),
+	string(missioncontrol.ServiceClassIdENTERPRISE1KSTANDALONE),
+	string(missioncontrol.ServiceClassIdENTERPRISE5KSTANDALONE),
+	string(missioncontrol.ServiceClassIdENTERPRISE10KSTANDALONE),
+	string(missioncontrol.ServiceClassIdENTERPRISE50KSTANDALONE),
+	string(missioncontrol.ServiceClassIdENTERPRISE100KSTANDALONE),
+}
+
+// validateServiceClass checks a service class against the ones Solace Cloud offers, so that new service classes can be
+// used as soon as they are launched. When they cannot be listed, the known service classes are used instead and an
+// unknown one only produces a warning, as it may simply be newer than this provider version.
+func (r *ServiceResource) validateServiceClass(ctx context.Context, serviceClassId string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	offered, err := r.serviceClassIds(ctx)
+	if err != nil {
+		if !slices.Contains(knownServiceClassIds, serviceClassId) {
+			diagnostics.AddAttributeWarning(
+				path.Root("service_class_id"),
+				"Unknown Service Class",
+				fmt.Sprintf("The service classes could not be listed from Solace Cloud while planning (%s) and %q is not "+
+					"one of the service classes known to this provider version.%s It will be validated when the service "+
+					"is created.", err, serviceClassId, util.DidYouMean(serviceClassId, knownServiceClassIds)),
+			)
+		}
+		return diagnostics
+	}
+
+	if !slices.Contains(offered, serviceClassId) {
+		diagnostics.AddAttributeError(
+			path.Root("service_class_id"),
+			"Service Class Not Offered",
+			fmt.Sprintf("Solace Cloud does not offer the service class %q.%s Offered service classes: %s.",
+				serviceClassId, util.DidYouMean(serviceClassId, offered), strings.Join(offered, ", ")),
+		)
+	}
+	return diagnostics
+}
+
+// serviceClassIds lists the service classes offered by Solace Cloud, cached for the rest of the provider run.
+func (r *ServiceResource) serviceClassIds(ctx context.Context) ([]string, error) {
+	fetch := func() ([]string, error) {
+		apiClientResp, err := r.APIClient.GetServiceClassesWithResponse(ctx, &missioncontrol.GetServiceClassesParams{})
+		if err != nil {
+			return nil, err
+		}
+		if apiClientResp.StatusCode() != http.StatusOK || apiClientResp.JSON200 == nil {
+			return nil, fmt.Errorf("received HTTP %d", apiClientResp.StatusCode())
+		}
+
+		var ids []string
+		for _, serviceClass := range apiClientResp.JSON200.Data {
+			if serviceClass.Id != nil {
+				ids = append(ids, string(*serviceClass.Id))
+			}
+		}
+		if len(ids) == 0 {
+			return nil, errors.New("no service classes returned")
+		}
+		return ids, nil
+	}
+
+	if r.ServiceClasses == nil {
+		return fetch()
+	}
+	return r.ServiceClasses.Get(fetch)
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"terraform-provider-solacecloud/internal/shared"
+	mc "terraform-provider-solacecloud/missioncontrol"
+	"testing"
+)
+
+func TestValidateServiceClass(t *testing.T) {
+	t.Run("new service class offered by the API", func(t *testing.T) {
+		client := &stubDatacenterClient{serviceClasses: []mc.ServiceClassId{"ENTERPRISE_500K_STANDALONE"}}
+		r := &ServiceResource{APIClient: NewRetryableClient(client, shared.RetryPolicy{}), ServiceClasses: &shared.ServiceClassCache{}}
+
+		for range 3 {
+			if diags := r.validateServiceClass(context.Background(), "ENTERPRISE_500K_STANDALONE"); len(diags) > 0 {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+		}
+		if diags := r.validateServiceClass(context.Background(), "DEVELOPER"); !diags.HasError() {
+			t.Errorf("expected an error for a service class that is not offered")
+		}
+		if client.classCalls != 1 {
+			t.Errorf("expected the service classes to be listed once, listed %d times", client.classCalls)
+		}
+	})
+
+	t.Run("offline fallback", func(t *testing.T) {
+		client := &stubDatacenterClient{err: errors.New("connection refused")}
+		r := &ServiceResource{APIClient: NewRetryableClient(client, shared.RetryPolicy{}), ServiceClasses: &shared.ServiceClassCache{}}
+
+		if diags := r.validateServiceClass(context.Background(), "ENTERPRISE_1K_STANDALONE"); len(diags) > 0 {
+			t.Errorf("unexpected diagnostics for a known service class: %v", diags)
+		}
+		diags := r.validateServiceClass(context.Background(), "ENTERPRISE_500K_STANDALONE")
+		if diags.HasError() || diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "Unknown Service Class" {
+			t.Errorf("expected an Unknown Service Class warning, got: %v", diags)
+		}
+		if client.classCalls != 2 {
+			t.Errorf("expected a failed listing not to be cached, listed %d times", client.classCalls)
+		}
+	})
+}
@@ -194,15 +194,73 @@ resource "solacecloud_service" "%s" {
 					return capturedServiceID, nil
 				},
 				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"custom_router_name",
-					"locked",
-				},
 			},
 		},
 	})
 }
 
+// TestServiceResourceImportThenPlan checks that the first plan after importing a service is empty, for standalone and
+// high availability services, with and without a custom router name.
+func TestServiceResourceImportThenPlan(t *testing.T) {
+	shapes := []struct {
+		name             string
+		serviceClass     string
+		customRouterName string
+	}{
+		{name: "developer", serviceClass: "DEVELOPER"},
+		{name: "standalone", serviceClass: "ENTERPRISE_250_STANDALONE"},
+		{name: "standalone_custom_router_name", serviceClass: "ENTERPRISE_1K_STANDALONE", customRouterName: "myrouter"},
+		{name: "high_availability", serviceClass: "ENTERPRISE_1K_HIGHAVAILABILITY"},
+		{name: "high_availability_custom_router_name", serviceClass: "ENTERPRISE_5K_HIGHAVAILABILITY", customRouterName: "myrouter"},
+	}
+
+	for _, shape := range shapes {
+		t.Run(shape.name, func(t *testing.T) {
+			instance := internal.NewTestInstance()
+			serviceName := "Import_Plan_Test_Service_" + random.String(8)
+			params := internal.ConfigurableParams{
+				ServiceClass:     shape.serviceClass,
+				ServiceName:      serviceName,
+				CustomRouterName: shape.customRouterName,
+			}
+			instance.Init(params)
+
+			customRouterName := ""
+			if shape.customRouterName != "" {
+				customRouterName = fmt.Sprintf("custom_router_name = %q", shape.customRouterName)
+			}
+			config := instance.GetBaseHcl() + fmt.Sprintf(`
+resource "solacecloud_service" "imported" {
+  name                 = "%s"
+  datacenter_id        = "eks-us-east-1"
+  service_class_id     = "%s"
+  mate_link_encryption = true
+  %s
+}
+`, serviceName, shape.serviceClass, customRouterName)
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+					},
+					{
+						Config:             config,
+						ResourceName:       "solacecloud_service.imported",
+						ImportState:        true,
+						ImportStatePersist: true,
+					},
+					{
+						Config:   config,
+						PlanOnly: true,
+					},
+				},
+			})
+		})
+	}
+}
+
 func TestNameValidation(t *testing.T) {
 	// Create a simple test provider factory that mocks the provider behavior
 	testAccProviderFactories := map[string]func() (tfprotov6.ProviderServer, error){
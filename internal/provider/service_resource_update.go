@@ -13,10 +13,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = shared.MaskSensitiveLogFields(ctx)
 	var state, plan ServiceResourceModel
 
 	// Read Terraform plan data into the model
@@ -32,8 +35,44 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, r.readDataToStruct(ctx, state.Id))...)
+	// Save updated data into Terraform state. The mapping keeps the planned value of the fields the response leaves
+	// out, so start from the plan.
+	newState, diags := plannedOrPriorState(ctx, req.Plan, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(*r.readDataInternal(ctx, &newState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, newState)...)
+}
+
+// plannedOrPriorState returns the planned values, with the ones only known after apply taken from the prior state, or
+// null when the prior state has none either.
+func plannedOrPriorState(ctx context.Context, plan tfsdk.Plan, state tfsdk.State) (ServiceResourceModel, diag.Diagnostics) {
+	var model ServiceResourceModel
+	var diags diag.Diagnostics
+
+	seeded, err := tftypes.Transform(plan.Raw, func(attributePath *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if value.IsKnown() {
+			return value, nil
+		}
+		if prior, _, err := tftypes.WalkAttributePath(state.Raw, attributePath); err == nil {
+			if priorValue, ok := prior.(tftypes.Value); ok && priorValue.IsFullyKnown() {
+				return priorValue, nil
+			}
+		}
+		return tftypes.NewValue(value.Type(), nil), nil
+	})
+	if err != nil {
+		diags.AddError("Error Reading Planned Service", "Could not merge the plan with the prior state: "+err.Error())
+		return model, diags
+	}
+
+	diags.Append(tfsdk.Plan{Schema: plan.Schema, Raw: seeded}.Get(ctx, &model)...)
+	return model, diags
 }
 
 func (r *ServiceResource) updateInternal(ctx context.Context, state *ServiceResourceModel, plan *ServiceResourceModel) diag.Diagnostics {
@@ -115,11 +154,11 @@ func (r *ServiceResource) patchService(ctx context.Context, state *ServiceResour
 	return diags
 }
 
-func (r *ServiceResource) readDataToStruct(ctx context.Context, serviceId basetypes.StringValue) ServiceResourceModel {
+func (r *ServiceResource) readDataToStruct(ctx context.Context, serviceId basetypes.StringValue) (ServiceResourceModel, diag.Diagnostics) {
 	var state ServiceResourceModel
 	state.Id = serviceId
-	r.readDataInternal(ctx, &state)
-	return state
+	diags := r.readDataInternal(ctx, &state)
+	return state, *diags
 }
 
 func (r *ServiceResource) updateStorageSize(ctx context.Context, state ServiceResourceModel, plan ServiceResourceModel) *diag.Diagnostics {
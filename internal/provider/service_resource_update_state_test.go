@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"terraform-provider-solacecloud/internal/shared"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUpdateKeepsPlannedValuesMissingFromResponse(t *testing.T) {
+	ctx := context.Background()
+	service := *testService(testMsgVpn("default"))
+	service.Id = ptr("svc1")
+	service.OwnedBy = ptr("new-owner")
+	// Router names that follow no known naming scheme, custom_router_name cannot be worked out from them.
+	service.Broker.Cluster.PrimaryRouterName = ptr("router-1")
+	service.Broker.Cluster.Name = nil
+	client := &stubServiceClient{getStatus: http.StatusOK, service: service}
+	r := &ServiceResource{APIClient: NewRetryableClient(client, shared.RetryPolicy{})}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	state, _ := plannedService(t, r, false)
+	var stateModel ServiceResourceModel
+	if diags := state.Get(ctx, &stateModel); diags.HasError() {
+		t.Fatalf("could not read state: %v", diags)
+	}
+	stateModel.Id = types.StringValue("svc1")
+	stateModel.OwnedBy = types.StringValue("owner")
+	stateModel.CustomRouterName = types.StringValue("myrouter")
+	stateModel.ClusterName = types.StringValue("my-cluster")
+	stateModel.UpdatedTime = types.StringValue("2025-01-01T00:00:00Z")
+	priorState := tfsdk.State{Schema: schemaResp.Schema, Raw: state.Raw}
+	if diags := priorState.Set(ctx, stateModel); diags.HasError() {
+		t.Fatalf("could not build state: %v", diags)
+	}
+
+	planModel := stateModel
+	planModel.OwnedBy = types.StringValue("new-owner")
+	planModel.UpdatedTime = types.StringUnknown()
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: priorState.Raw}
+	if diags := plan.Set(ctx, planModel); diags.HasError() {
+		t.Fatalf("could not build plan: %v", diags)
+	}
+
+	resp := &resource.UpdateResponse{State: priorState}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: priorState}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var updated ServiceResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &updated)...)
+	if updated.OwnedBy.ValueString() != "new-owner" {
+		t.Errorf("expected the updated owned_by, got %s", updated.OwnedBy)
+	}
+	if updated.CustomRouterName.ValueString() != "myrouter" || updated.ClusterName.ValueString() != "my-cluster" {
+		t.Errorf("expected the planned custom_router_name and cluster_name to be kept, got %s and %s",
+			updated.CustomRouterName, updated.ClusterName)
+	}
+	if !resp.State.Raw.IsFullyKnown() {
+		t.Errorf("expected no unknown values in the state, got %s", resp.State.Raw)
+	}
+}
@@ -4,9 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// replaceOnImmutableChangeAttribute is the resource attribute which opts a service into being replaced, rather than
+// failing the plan, when one of its immutable attributes changes.
+const replaceOnImmutableChangeAttribute = "replace_on_immutable_change"
+
 // ImmutableStringPlanModifier is a plan modifier that prevents changes to a string attribute
 type ImmutableStringPlanModifier struct{}
 
@@ -43,6 +50,13 @@ func validateImmutableNotChanged(ctx context.Context, req planmodifier.StringReq
 	}
 
 	if req.PlanValue.ValueString() != req.StateValue.ValueString() {
+		// Replacement was requested, or left to the provider-wide setting which is resolved in the resource's
+		// ModifyPlan, so let the change through as a replacement.
+		if !replaceOnImmutableChangeOptedOut(ctx, req.Config) {
+			resp.RequiresReplace = true
+			return
+		}
+
 		// Add error diagnostic
 		resp.Diagnostics.AddAttributeError(
 			req.Path,
@@ -57,7 +71,25 @@ func validateImmutableNotChanged(ctx context.Context, req planmodifier.StringReq
 	}
 }
 
-// ImmutableStringPlanModifier is a plan modifier that prevents changes to a string attribute
+// replaceOnImmutableChangeOptedOut reports whether the resource configuration rules out replacing the resource on an
+// immutable attribute change. Only an explicit false does, an unset value defers to the provider-wide setting.
+// Without a configuration to consult we keep the strict behaviour.
+func replaceOnImmutableChangeOptedOut(ctx context.Context, config tfsdk.Config) bool {
+	if config.Raw.IsNull() {
+		return true
+	}
+
+	var replace types.Bool
+	diags := config.GetAttribute(ctx, path.Root(replaceOnImmutableChangeAttribute), &replace)
+	if diags.HasError() {
+		return true
+	}
+
+	return !replace.IsNull() && !replace.IsUnknown() && !replace.ValueBool()
+}
+
+// CustomRouterNamePlanModifier marks custom_router_name changes for replacement, including adding or removing it. A
+// change is reported as an error by the resource's ModifyPlan when the service cannot be replaced.
 type CustomRouterNamePlanModifier struct{}
 
 // Description returns a plain text description of the plan modifier
@@ -87,13 +119,9 @@ func (m CustomRouterNamePlanModifier) PlanModifyString(ctx context.Context, req
 		return
 	}
 
-	resp.Diagnostics.AddAttributeError(
-		req.Path,
-		"Immutable Attribute Change",
-		fmt.Sprintf("You cannot change this attribute after resource creation. "+
-			"State value: %q, Planned value: %q",
-			req.StateValue.ValueString(), req.PlanValue.ValueString()),
-	)
+	if !replaceOnImmutableChangeOptedOut(ctx, req.Config) {
+		resp.RequiresReplace = true
+	}
 }
 
 func NewCustomRouterNamePlanModifier() planmodifier.String {
@@ -5,8 +5,11 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestImmutableStringPlanModifier_Description(t *testing.T) {
@@ -201,3 +204,102 @@ func TestValidateImmutableNotChanged(t *testing.T) {
 		})
 	}
 }
+
+func TestImmutableStringPlanModifier_ReplaceOnImmutableChange(t *testing.T) {
+	tests := []struct {
+		name                  string
+		replaceValue          tftypes.Value
+		expectError           bool
+		expectRequiresReplace bool
+	}{
+		{
+			name:                  "opted in - requires replace",
+			replaceValue:          tftypes.NewValue(tftypes.Bool, true),
+			expectError:           false,
+			expectRequiresReplace: true,
+		},
+		{
+			name:                  "unset - deferred to the provider setting",
+			replaceValue:          tftypes.NewValue(tftypes.Bool, nil),
+			expectError:           false,
+			expectRequiresReplace: true,
+		},
+		{
+			name:                  "opted out - should error",
+			replaceValue:          tftypes.NewValue(tftypes.Bool, false),
+			expectError:           true,
+			expectRequiresReplace: false,
+		},
+	}
+
+	configSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"test":                            schema.StringAttribute{Optional: true},
+			replaceOnImmutableChangeAttribute: schema.BoolAttribute{Optional: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			config := tfsdk.Config{
+				Schema: configSchema,
+				Raw: tftypes.NewValue(configSchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"test":                            tftypes.NewValue(tftypes.String, "new"),
+					replaceOnImmutableChangeAttribute: tt.replaceValue,
+				}),
+			}
+
+			req := planmodifier.StringRequest{
+				Path:       path.Root("test"),
+				Config:     config,
+				StateValue: types.StringValue("old"),
+				PlanValue:  types.StringValue("new"),
+			}
+			resp := &planmodifier.StringResponse{
+				PlanValue: req.PlanValue,
+			}
+
+			ImmutableStringPlanModifier{}.PlanModifyString(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Errorf("Expected error: %v, got error: %v", tt.expectError, resp.Diagnostics.HasError())
+			}
+			if resp.RequiresReplace != tt.expectRequiresReplace {
+				t.Errorf("Expected RequiresReplace: %v, got: %v", tt.expectRequiresReplace, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestCustomRouterNamePlanModifier_LeavesErrorToModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	configSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"test":                            schema.StringAttribute{Optional: true},
+			replaceOnImmutableChangeAttribute: schema.BoolAttribute{Optional: true},
+		},
+	}
+	config := tfsdk.Config{
+		Schema: configSchema,
+		Raw: tftypes.NewValue(configSchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"test":                            tftypes.NewValue(tftypes.String, "new"),
+			replaceOnImmutableChangeAttribute: tftypes.NewValue(tftypes.Bool, false),
+		}),
+	}
+	req := planmodifier.StringRequest{
+		Path:       path.Root("test"),
+		Config:     config,
+		Plan:       tfsdk.Plan(config),
+		State:      tfsdk.State(config),
+		StateValue: types.StringValue("old"),
+		PlanValue:  types.StringValue("new"),
+	}
+	resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+	CustomRouterNamePlanModifier{}.PlanModifyString(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() || resp.RequiresReplace {
+		t.Errorf("Expected the change to be left to ModifyPlan, got error %v and RequiresReplace %v", resp.Diagnostics, resp.RequiresReplace)
+	}
+}
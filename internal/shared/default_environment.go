@@ -0,0 +1,36 @@
+package shared
+
+import (
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// DefaultEnvironment is the default_environment of the provider, the name or identifier of the environment of the
+// services that do not set one. It is resolved to an environment identifier at most once per provider run, when the
+// first service needs it.
+type DefaultEnvironment struct {
+	NameOrId string
+
+	mu       sync.Mutex
+	resolved bool
+	id       string
+}
+
+// Id returns the resolved environment identifier, calling resolve the first time. A failed resolution is not cached,
+// like the service classes, so that each service reports the error.
+func (e *DefaultEnvironment) Id(resolve func(nameOrId string) (string, diag.Diagnostics)) (string, diag.Diagnostics) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.resolved {
+		return e.id, nil
+	}
+	id, diagnostics := resolve(e.NameOrId)
+	if diagnostics.HasError() {
+		return "", diagnostics
+	}
+	e.id = id
+	e.resolved = true
+	return id, diagnostics
+}
@@ -0,0 +1,115 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sensitiveLogFieldKeys are the log fields whose value is always masked.
+var sensitiveLogFieldKeys = []string{"Authorization", "api_token", "password", "token", "privateKey"}
+
+// sensitiveHeaders are the HTTP headers whose value is never logged.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization"}
+
+var (
+	// sensitiveJSONProperty matches the JSON string properties holding secrets, such as password, dmrClusterPassword,
+	// token or privateKey, capturing everything up to the value.
+	sensitiveJSONProperty = regexp.MustCompile(`(?i)("[a-z0-9_]*(?:password|token|privatekey)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// bearerToken matches the credentials of an Authorization header.
+	bearerToken = regexp.MustCompile(`(?i)\bbearer\s+[a-z0-9\-._~+/]+=*`)
+)
+
+// RedactBody returns an API request or response body with the values of its password, token and private key
+// properties replaced, so that it can be logged.
+func RedactBody(body []byte) string {
+	return sensitiveJSONProperty.ReplaceAllString(string(body), `$1"***"`)
+}
+
+// MaskSensitiveLogFields returns a context whose logs have the secrets masked: the values of the password, token,
+// privateKey and Authorization fields, and the secrets found in messages and other field values. The provider, its
+// resources and data sources call it first thing in every method handed a context by Terraform.
+func MaskSensitiveLogFields(ctx context.Context) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogFieldKeys...)
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, sensitiveJSONProperty, bearerToken)
+	return tflog.MaskMessageRegexes(ctx, sensitiveJSONProperty, bearerToken)
+}
+
+// LoggingTransport logs every request sent to Solace Cloud and its response: the method, URL, status code and
+// duration at debug level, and the headers and bodies at trace level, with their secrets redacted.
+type LoggingTransport struct {
+	Transport http.RoundTripper
+}
+
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := MaskSensitiveLogFields(req.Context())
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    req.URL.String(),
+	}
+
+	tflog.Debug(ctx, "Sending Solace Cloud API request", fields)
+	tflog.Trace(ctx, "Solace Cloud API request details", fields, map[string]interface{}{
+		"http_request_headers": redactHeaders(req.Header),
+		"http_request_body":    requestBody(req),
+	})
+
+	start := time.Now()
+	resp, err := t.Transport.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.Debug(ctx, "Solace Cloud API request failed", fields, map[string]interface{}{"error": err.Error()})
+		return resp, err
+	}
+
+	fields["http_status_code"] = resp.StatusCode
+	tflog.Debug(ctx, "Received Solace Cloud API response", fields)
+
+	// The body is read up front to be logged, the generated clients read it whole anyway.
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	tflog.Trace(ctx, "Solace Cloud API response details", fields, map[string]interface{}{
+		"http_response_headers": redactHeaders(resp.Header),
+		"http_response_body":    RedactBody(body),
+	})
+	return resp, nil
+}
+
+// requestBody returns the redacted body of a request, without consuming it.
+func requestBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	return RedactBody(payload)
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for name := range header {
+		redacted[name] = header.Get(name)
+	}
+	for _, name := range sensitiveHeaders {
+		if _, ok := redacted[name]; ok {
+			redacted[name] = "***"
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,58 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// PermissionRequirement is what an action needs: any one of the token permissions listed by Solace Cloud for its API
+// calls.
+type PermissionRequirement struct {
+	Action string
+	AnyOf  []string
+}
+
+// The permissions of the API calls made by the provider, as listed in the Mission Control and Platform API specs.
+var (
+	ReadServicesPermission = PermissionRequirement{"read services",
+		[]string{"mission_control:access", "services:get", "services:get:self", "services:view", "services:view:self"}}
+	CreateServicesPermission = PermissionRequirement{"create services", []string{"services:post"}}
+	UpdateServicesPermission = PermissionRequirement{"update services", []string{"mission_control:access", "services:put"}}
+	DeleteServicesPermission = PermissionRequirement{"delete services",
+		[]string{"services:delete", "services:delete:self", "mission_control:access"}}
+	ReadEnvironmentsPermission = PermissionRequirement{"read environments", []string{"environments:view"}}
+)
+
+// Permissions are the permissions of the API token, found by the preflight check of the provider. They are nil when
+// the preflight check is disabled or could not find them, and every permission is then assumed to be granted.
+type Permissions map[string]bool
+
+// Check returns an error listing the requirements of a resource type that are not met.
+func (p Permissions) Check(resourceType string, requirements ...PermissionRequirement) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	if p == nil {
+		return diagnostics
+	}
+
+	var missing []string
+	for _, requirement := range requirements {
+		granted := false
+		for _, permission := range requirement.AnyOf {
+			granted = granted || p[permission]
+		}
+		if !granted {
+			missing = append(missing, fmt.Sprintf("- to %s: %s", requirement.Action, strings.Join(requirement.AnyOf, " or ")))
+		}
+	}
+	if len(missing) > 0 {
+		diagnostics.AddError(
+			"Missing API Token Permissions",
+			fmt.Sprintf("The roles of the API token do not grant the permissions %s needs:\n%s\n\n"+
+				"Create a token with these permissions, or set preflight = false on the provider to skip this check.",
+				resourceType, strings.Join(missing, "\n")),
+		)
+	}
+	return diagnostics
+}
@@ -0,0 +1,26 @@
+package shared
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPermissionsCheck(t *testing.T) {
+	if diags := Permissions(nil).Check("solacecloud_service", CreateServicesPermission); diags.HasError() {
+		t.Errorf("expected unknown permissions to pass, got %v", diags)
+	}
+
+	permissions := Permissions{"services:view": true, "mission_control:access": true}
+	if diags := permissions.Check("solacecloud_service", ReadServicesPermission, UpdateServicesPermission); diags.HasError() {
+		t.Errorf("expected the permissions to be granted, got %v", diags)
+	}
+
+	diags := permissions.Check("solacecloud_environment", ReadServicesPermission, ReadEnvironmentsPermission)
+	if len(diags) != 1 || diags[0].Summary() != "Missing API Token Permissions" {
+		t.Fatalf("expected a missing permissions error, got %v", diags)
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, "to read environments: environments:view") ||
+		strings.Contains(detail, "read services") {
+		t.Errorf("expected only the environments permission to be listed, got %q", detail)
+	}
+}
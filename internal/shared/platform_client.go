@@ -0,0 +1,36 @@
+package shared
+
+import (
+	"context"
+	"net/http"
+	"terraform-provider-solacecloud/platform"
+)
+
+// PlatformClient is the part of the platform API used by the provider.
+type PlatformClient interface {
+	SearchEnvironmentsWithResponse(ctx context.Context, params *platform.SearchEnvironmentsParams, reqEditors ...platform.RequestEditorFn) (*platform.SearchEnvironmentsResponse, error)
+	GetEnvironmentByIdWithResponse(ctx context.Context, id string, reqEditors ...platform.RequestEditorFn) (*platform.GetEnvironmentByIdResponse, error)
+}
+
+// RetryablePlatformClient retries the platform API calls that fail while Solace Cloud is rate limiting requests or
+// temporarily unavailable, following a RetryPolicy. All of them only read, so they are always safe to retry.
+type RetryablePlatformClient struct {
+	api    PlatformClient
+	policy RetryPolicy
+}
+
+func NewRetryablePlatformClient(api PlatformClient, policy RetryPolicy) *RetryablePlatformClient {
+	return &RetryablePlatformClient{api, policy}
+}
+
+func (w *RetryablePlatformClient) SearchEnvironmentsWithResponse(ctx context.Context, params *platform.SearchEnvironmentsParams, reqEditors ...platform.RequestEditorFn) (*platform.SearchEnvironmentsResponse, error) {
+	return Retry(ctx, w.policy, true, func() (*platform.SearchEnvironmentsResponse, error) {
+		return w.api.SearchEnvironmentsWithResponse(ctx, params, reqEditors...)
+	}, func(resp *platform.SearchEnvironmentsResponse) *http.Response { return resp.HTTPResponse })
+}
+
+func (w *RetryablePlatformClient) GetEnvironmentByIdWithResponse(ctx context.Context, id string, reqEditors ...platform.RequestEditorFn) (*platform.GetEnvironmentByIdResponse, error) {
+	return Retry(ctx, w.policy, true, func() (*platform.GetEnvironmentByIdResponse, error) {
+		return w.api.GetEnvironmentByIdWithResponse(ctx, id, reqEditors...)
+	}, func(resp *platform.GetEnvironmentByIdResponse) *http.Response { return resp.HTTPResponse })
+}
@@ -8,7 +8,16 @@ import (
 // ProviderConfig maps provider schema data to a Go type.
 // This type is shared between the provider and data sources/resources.
 type ProviderConfig struct {
-	APIClient          *missioncontrol.ClientWithResponses
-	APIPollingInterval int
-	PlatformClient     *platform.ClientWithResponses
+	APIClient                *missioncontrol.ClientWithResponses
+	APIPollingInterval       int
+	DefaultDatacenterId      string
+	DefaultEnvironment       *DefaultEnvironment
+	DefaultServiceClassId    string
+	OrganizationId           string
+	Permissions              Permissions
+	PlatformClient           *platform.ClientWithResponses
+	RateLimiter              *RateLimiter
+	ReplaceOnImmutableChange bool
+	RetryPolicy              RetryPolicy
+	ServiceClasses           *ServiceClassCache
 }
@@ -0,0 +1,105 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RateLimiter is a token bucket limiting the rate of the requests sent to Solace Cloud. A single limiter is shared by
+// all the clients of a provider run, so that the resources planned and applied concurrently do not get throttled.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRateLimiter allows requestsPerSecond requests per second on average, and up to burst requests at once. A rate
+// of 0 or less disables the limit.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	burst = max(burst, 1)
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent, and returns how long it waited.
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	delay := l.reserve()
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return 0, ctx.Err()
+	case <-timer.C:
+		return delay, nil
+	}
+}
+
+// reserve takes a token and returns how long to wait until it becomes available. The bucket goes negative while
+// requests are waiting, so that they are let through in turn.
+func (l *RateLimiter) reserve() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.burst)
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel gives back the token of a request that stopped waiting.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.tokens+1, l.burst)
+}
+
+// HTTPRequestDoer sends HTTP requests, it matches the HttpRequestDoer of both generated clients.
+type HTTPRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RateLimitedDoer waits for the rate limiter before sending each request.
+type RateLimitedDoer struct {
+	Doer    HTTPRequestDoer
+	Limiter *RateLimiter
+}
+
+func (d *RateLimitedDoer) Do(req *http.Request) (*http.Response, error) {
+	delay, err := d.Limiter.Wait(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	if delay > 0 {
+		tflog.Debug(req.Context(), fmt.Sprintf("Delayed %s %s by %s to stay within the Solace Cloud API rate limit",
+			req.Method, req.URL.Path, delay.Round(time.Millisecond)))
+	}
+	return d.Doer.Do(req)
+}
@@ -0,0 +1,85 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(2, 3)
+	limiter.now = func() time.Time { return now }
+
+	// The burst goes through straight away, then requests are spaced by 1/rate.
+	for i, expected := range []time.Duration{0, 0, 0, 500 * time.Millisecond, time.Second} {
+		if delay := limiter.reserve(); delay != expected {
+			t.Errorf("request %d: expected a delay of %s, got %s", i, expected, delay)
+		}
+	}
+
+	// Tokens come back over time, up to the burst.
+	now = now.Add(time.Hour)
+	for i := range 3 {
+		if delay := limiter.reserve(); delay != 0 {
+			t.Errorf("request %d after an hour: expected no delay, got %s", i, delay)
+		}
+	}
+	if delay := limiter.reserve(); delay != 500*time.Millisecond {
+		t.Errorf("expected the burst to be capped, got a delay of %s", delay)
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	limiter := NewRateLimiter(0, 1)
+	for range 100 {
+		if delay := limiter.reserve(); delay != 0 {
+			t.Fatalf("expected no delay, got %s", delay)
+		}
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	limiter := NewRateLimiter(0.001, 1)
+	limiter.reserve()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := limiter.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	// The cancelled request gave its token back, the next one waits for a single token only.
+	if delay := limiter.reserve(); delay > 1001*time.Second {
+		t.Errorf("expected a delay of at most one token, got %s", delay)
+	}
+}
+
+type countingDoer struct {
+	calls int
+}
+
+func (d *countingDoer) Do(_ *http.Request) (*http.Response, error) {
+	d.calls++
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestRateLimitedDoer(t *testing.T) {
+	doer := &countingDoer{}
+	rateLimited := &RateLimitedDoer{Doer: doer, Limiter: NewRateLimiter(1000, 2)}
+
+	start := time.Now()
+	for range 4 {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.solace.cloud/api/v2/missionControl/eventBrokerServices", nil)
+		if _, err := rateLimited.Do(req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if doer.calls != 4 {
+		t.Errorf("expected 4 requests, got %d", doer.calls)
+	}
+	if elapsed := time.Since(start); elapsed < 2*time.Millisecond {
+		t.Errorf("expected the requests after the burst to be delayed, took %s", elapsed)
+	}
+}
@@ -0,0 +1,128 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryPolicy decides how API calls are retried while Solace Cloud is rate limiting requests or temporarily unavailable.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a call is retried after its first attempt.
+	MaxRetries int
+	// MinBackoff is the delay before the first retry, doubled for every following one up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is used when the provider configuration does not change it.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+// Retry calls fn until it succeeds, fails in a way that is not worth retrying or runs out of retries, and returns the
+// last result. httpResponse gives access to the HTTP response of a result, for its status code and Retry-After header.
+//
+// Responses with HTTP status 429 or 5xx and transport errors are only retried when the call is idempotent, as the
+// server may already have processed a request it failed to answer. Errors that happen before the request is sent,
+// such as a host that cannot be resolved or a refused connection, are retried for every call. Failures to get an API
+// token are never retried.
+func Retry[T any](ctx context.Context, policy RetryPolicy, idempotent bool, fn func() (T, error), httpResponse func(T) *http.Response) (T, error) {
+	for attempt := 0; ; attempt++ {
+		result, err := fn()
+
+		var reason string
+		var retryAfter time.Duration
+		switch {
+		case err != nil:
+			if ctx.Err() != nil || errors.Is(err, ErrAPITokenUnavailable) || !(idempotent || requestNotSent(err)) {
+				return result, err
+			}
+			reason = err.Error()
+		case !idempotent:
+			return result, err
+		default:
+			resp := httpResponse(result)
+			if resp == nil || !isRetryableStatus(resp.StatusCode) {
+				return result, err
+			}
+			reason = fmt.Sprintf("received HTTP %d", resp.StatusCode)
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+
+		if attempt >= policy.MaxRetries {
+			return result, err
+		}
+
+		delay := max(policy.backoff(attempt), retryAfter)
+		tflog.Warn(ctx, fmt.Sprintf("Retrying Solace Cloud API call in %s (retry %d of %d): %s",
+			delay.Round(time.Millisecond), attempt+1, policy.MaxRetries, reason))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay before the given retry: exponential, capped at MaxBackoff, with a random jitter of up to
+// half of it so that clients rate limited together do not retry together.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MinBackoff
+	for range attempt {
+		if delay >= p.MaxBackoff/2 {
+			delay = p.MaxBackoff
+			break
+		}
+		delay *= 2
+	}
+	delay = min(delay, p.MaxBackoff)
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// requestNotSent reports whether err shows that the request never reached the server, so that sending it again cannot
+// duplicate it.
+func requestNotSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "proxyconnect")
+}
+
+// parseRetryAfter reads a Retry-After header, given either in seconds or as an HTTP date. It returns 0 when the header
+// is missing or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
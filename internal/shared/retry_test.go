@@ -0,0 +1,173 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func statusResponse(statusCode int, header http.Header) *http.Response {
+	return &http.Response{StatusCode: statusCode, Header: header}
+}
+
+func responseOf(resp *http.Response) *http.Response {
+	return resp
+}
+
+func TestRetry(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2}
+	dialErr := &url.Error{Op: "Post", URL: "https://api.solace.cloud", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	resetErr := &url.Error{Op: "Post", URL: "https://api.solace.cloud", Err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}}
+
+	tests := []struct {
+		name          string
+		idempotent    bool
+		responses     []*http.Response
+		errs          []error
+		expectedCalls int
+		expectedCode  int
+		expectError   bool
+	}{
+		{
+			name:          "success",
+			idempotent:    true,
+			responses:     []*http.Response{statusResponse(http.StatusOK, nil)},
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "rate limited then success",
+			idempotent:    true,
+			responses:     []*http.Response{statusResponse(http.StatusTooManyRequests, nil), statusResponse(http.StatusOK, nil)},
+			expectedCalls: 2,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:       "unavailable until retries run out",
+			idempotent: true,
+			responses: []*http.Response{statusResponse(http.StatusServiceUnavailable, nil),
+				statusResponse(http.StatusBadGateway, nil), statusResponse(http.StatusServiceUnavailable, nil)},
+			expectedCalls: 3,
+			expectedCode:  http.StatusServiceUnavailable,
+		},
+		{
+			name:          "client errors are not retried",
+			idempotent:    true,
+			responses:     []*http.Response{statusResponse(http.StatusNotFound, nil)},
+			expectedCalls: 1,
+			expectedCode:  http.StatusNotFound,
+		},
+		{
+			name:          "not idempotent",
+			responses:     []*http.Response{statusResponse(http.StatusServiceUnavailable, nil)},
+			expectedCalls: 1,
+			expectedCode:  http.StatusServiceUnavailable,
+		},
+		{
+			name:          "not idempotent and never sent",
+			responses:     []*http.Response{nil, statusResponse(http.StatusAccepted, nil)},
+			errs:          []error{dialErr, nil},
+			expectedCalls: 2,
+			expectedCode:  http.StatusAccepted,
+		},
+		{
+			name:          "not idempotent and possibly received",
+			responses:     []*http.Response{nil},
+			errs:          []error{resetErr},
+			expectedCalls: 1,
+			expectError:   true,
+		},
+		{
+			name:          "idempotent transport error",
+			idempotent:    true,
+			responses:     []*http.Response{nil, statusResponse(http.StatusOK, nil)},
+			errs:          []error{resetErr, nil},
+			expectedCalls: 2,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "no API token",
+			idempotent:    true,
+			responses:     []*http.Response{nil},
+			errs:          []error{&url.Error{Op: "Get", URL: "https://api.solace.cloud", Err: ErrAPITokenUnavailable}},
+			expectedCalls: 1,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			resp, err := Retry(context.Background(), policy, tt.idempotent, func() (*http.Response, error) {
+				i := calls
+				calls++
+				var err error
+				if i < len(tt.errs) {
+					err = tt.errs[i]
+				}
+				return tt.responses[i], err
+			}, responseOf)
+
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectedCalls, calls)
+			}
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error %v, got %v", tt.expectError, err)
+			}
+			if !tt.expectError && resp.StatusCode != tt.expectedCode {
+				t.Errorf("expected HTTP %d, got %d", tt.expectedCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := Retry(ctx, RetryPolicy{MaxRetries: 5, MinBackoff: time.Hour, MaxBackoff: time.Hour}, true, func() (*http.Response, error) {
+		calls++
+		cancel()
+		return statusResponse(http.StatusServiceUnavailable, nil), nil
+	}, responseOf)
+
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("expected a single call ending with context.Canceled, got %d calls and %v", calls, err)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		for range 20 {
+			delay := policy.backoff(attempt)
+			if delay < expected/2 || delay > expected {
+				t.Fatalf("attempt %d: expected a delay between %s and %s, got %s", attempt, expected/2, expected, delay)
+			}
+		}
+	}
+
+	if delay := (RetryPolicy{}).backoff(3); delay != 0 {
+		t.Errorf("expected no delay without backoff, got %s", delay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-5":                            0,
+		"soon":                          0,
+		"Wed, 01 Jan 2025 12:00:30 GMT": 30 * time.Second,
+		"Wed, 01 Jan 2025 11:59:00 GMT": 0,
+	}
+	for value, expected := range tests {
+		if delay := parseRetryAfter(value, now); delay != expected {
+			t.Errorf("Retry-After %q: expected %s, got %s", value, expected, delay)
+		}
+	}
+}
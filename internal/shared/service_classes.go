@@ -0,0 +1,31 @@
+package shared
+
+import (
+	"sync"
+)
+
+// ServiceClassCache holds the service classes offered by Mission Control. They are fetched at most once per provider
+// run and shared by every resource, so that planning many services does not list the service classes for each one.
+type ServiceClassCache struct {
+	mu      sync.Mutex
+	fetched bool
+	ids     []string
+}
+
+// Get returns the cached service class identifiers, calling fetch the first time. A failed fetch is not cached, so
+// that a transient error does not disable validation for the rest of the run.
+func (c *ServiceClassCache) Get(fetch func() ([]string, error)) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fetched {
+		return c.ids, nil
+	}
+	ids, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.ids = ids
+	c.fetched = true
+	return ids, nil
+}
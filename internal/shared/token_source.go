@@ -0,0 +1,229 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAPITokenUnavailable is returned by the requests that could not be sent because no API token could be obtained.
+// Retrying them would only run the same failing command or token request again.
+var ErrAPITokenUnavailable = errors.New("unable to get a Solace Cloud API token")
+
+// tokenExpiryMargin is how long before its expiry a token is renewed, so that it does not expire in flight.
+const tokenExpiryMargin = 30 * time.Second
+
+// tokenCommandTimeout bounds the run of an api_token_command.
+const tokenCommandTimeout = time.Minute
+
+// TokenSource provides the API token sent with every request. Tokens are cached until they expire or the API rejects
+// them, so that short-lived tokens can be used.
+type TokenSource struct {
+	mu     sync.Mutex
+	fetch  func(ctx context.Context) (string, time.Time, error)
+	token  string
+	expiry time.Time
+	now    func() time.Time
+}
+
+func newTokenSource(fetch func(ctx context.Context) (string, time.Time, error)) *TokenSource {
+	return &TokenSource{fetch: fetch, now: time.Now}
+}
+
+// NewStaticTokenSource always provides the same token.
+func NewStaticTokenSource(token string) *TokenSource {
+	return newTokenSource(func(context.Context) (string, time.Time, error) {
+		return token, time.Time{}, nil
+	})
+}
+
+// NewFileTokenSource reads the token from a file, and reads it again when the API rejects it, for files kept up to
+// date by a secrets agent.
+func NewFileTokenSource(path string) *TokenSource {
+	return newTokenSource(func(context.Context) (string, time.Time, error) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		token := strings.TrimSpace(string(content))
+		if token == "" {
+			return "", time.Time{}, fmt.Errorf("%s is empty", path)
+		}
+		return token, time.Time{}, nil
+	})
+}
+
+// NewCommandTokenSource runs a credential helper command, through the shell, that prints the token on its standard
+// output. The command runs again when the API rejects the token.
+func NewCommandTokenSource(command string) *TokenSource {
+	return newTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+		defer cancel()
+
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+		} else {
+			cmd = exec.CommandContext(ctx, "sh", "-c", command)
+		}
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return "", time.Time{}, fmt.Errorf("api token command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		token := strings.TrimSpace(stdout.String())
+		if token == "" {
+			return "", time.Time{}, errors.New("api token command printed no token")
+		}
+		return token, time.Time{}, nil
+	})
+}
+
+// ClientCredentials configures the OAuth2 client credentials flow.
+type ClientCredentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// NewClientCredentialsTokenSource requests access tokens from an OAuth2 authorization server with the client
+// credentials grant, sending the client ID and secret with HTTP basic authentication. Tokens are renewed shortly
+// before they expire.
+func NewClientCredentialsTokenSource(credentials ClientCredentials, client HTTPRequestDoer) *TokenSource {
+	source := newTokenSource(nil)
+	source.fetch = func(ctx context.Context) (string, time.Time, error) {
+		form := url.Values{"grant_type": {"client_credentials"}}
+		if len(credentials.Scopes) > 0 {
+			form.Set("scope", strings.Join(credentials.Scopes, " "))
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, credentials.TokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+		req.SetBasicAuth(url.QueryEscape(credentials.ClientID), url.QueryEscape(credentials.ClientSecret))
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+
+		var tokenResp struct {
+			AccessToken      string `json:"access_token"`
+			ExpiresIn        int64  `json:"expires_in"`
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if err := json.Unmarshal(body, &tokenResp); err != nil && resp.StatusCode == http.StatusOK {
+			return "", time.Time{}, fmt.Errorf("invalid token response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK || tokenResp.AccessToken == "" {
+			return "", time.Time{}, fmt.Errorf("token request failed with HTTP %d: %s %s",
+				resp.StatusCode, tokenResp.Error, tokenResp.ErrorDescription)
+		}
+
+		var expiry time.Time
+		if tokenResp.ExpiresIn > 0 {
+			expiry = source.now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+		}
+		return tokenResp.AccessToken, expiry, nil
+	}
+	return source
+}
+
+// Token returns the cached token, or gets a new one when there is none or it is about to expire.
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || s.now().Before(s.expiry.Add(-tokenExpiryMargin))) {
+		return s.token, nil
+	}
+	token, expiry, err := s.fetch(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrAPITokenUnavailable, err)
+	}
+	s.token, s.expiry = token, expiry
+	return token, nil
+}
+
+// Invalidate drops the given token from the cache after the API rejected it. Requests that failed with an older token
+// do not drop a token that was already renewed.
+func (s *TokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// noTokenRenewalKey marks the contexts of the requests whose HTTP 401 answers must not renew the token.
+type noTokenRenewalKey struct{}
+
+// WithoutTokenRenewal returns a context whose requests keep an HTTP 401 answer, rather than renewing the token and
+// sending the request again. It is meant for requests expected to be rejected, such as those probing the home clouds.
+func WithoutTokenRenewal(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noTokenRenewalKey{}, true)
+}
+
+// BearerTokenTransport sets the API token of every request. When the API answers with HTTP 401, the token is renewed
+// and the request sent again once, if the renewed token is a different one and the request context allows it.
+type BearerTokenTransport struct {
+	Transport http.RoundTripper
+	Source    *TokenSource
+}
+
+func (t *BearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Source.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.Transport.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || (req.Body != nil && req.GetBody == nil) ||
+		req.Context().Value(noTokenRenewalKey{}) != nil {
+		return resp, err
+	}
+
+	t.Source.Invalidate(token)
+	renewed, err := t.Source.Token(req.Context())
+	if err != nil || renewed == token {
+		// Keep the 401, it tells more about the failure than the token error.
+		return resp, nil
+	}
+	retry := withBearerToken(req, renewed)
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return t.Transport.RoundTrip(retry)
+}
+
+// withBearerToken returns a copy of the request with its Authorization header set, as a RoundTripper must not change
+// the request it is given.
+func withBearerToken(req *http.Request, token string) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", "Bearer "+token)
+	return clone
+}
@@ -0,0 +1,204 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingTokenSource returns token-1, token-2... on every fetch.
+func countingTokenSource(expiresIn time.Duration) (*TokenSource, *int) {
+	fetches := 0
+	var source *TokenSource
+	source = newTokenSource(func(context.Context) (string, time.Time, error) {
+		fetches++
+		var expiry time.Time
+		if expiresIn > 0 {
+			expiry = source.now().Add(expiresIn)
+		}
+		return fmt.Sprintf("token-%d", fetches), expiry, nil
+	})
+	return source, &fetches
+}
+
+func TestTokenSourceCaching(t *testing.T) {
+	ctx := context.Background()
+	source, fetches := countingTokenSource(0)
+
+	for range 3 {
+		if token, err := source.Token(ctx); err != nil || token != "token-1" {
+			t.Fatalf("expected the cached token-1, got %s, %v", token, err)
+		}
+	}
+
+	// Invalidating a token that was already renewed keeps the current one.
+	source.Invalidate("token-0")
+	if token, _ := source.Token(ctx); token != "token-1" || *fetches != 1 {
+		t.Errorf("expected token-1 after 1 fetch, got %s after %d", token, *fetches)
+	}
+
+	source.Invalidate("token-1")
+	if token, _ := source.Token(ctx); token != "token-2" || *fetches != 2 {
+		t.Errorf("expected token-2 after 2 fetches, got %s after %d", token, *fetches)
+	}
+}
+
+func TestTokenSourceExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	source, _ := countingTokenSource(5 * time.Minute)
+	source.now = func() time.Time { return now }
+
+	_, _ = source.Token(ctx)
+	now = now.Add(4 * time.Minute)
+	if token, _ := source.Token(ctx); token != "token-1" {
+		t.Errorf("expected token-1 to still be valid, got %s", token)
+	}
+	// Renewed ahead of its expiry.
+	now = now.Add(45 * time.Second)
+	if token, _ := source.Token(ctx); token != "token-2" {
+		t.Errorf("expected token-1 to be renewed, got %s", token)
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	source := NewFileTokenSource(file)
+	if token, err := source.Token(ctx); err != nil || token != "file-token" {
+		t.Errorf("expected file-token, got %s, %v", token, err)
+	}
+
+	// The file is read again once the token is rejected.
+	if err := os.WriteFile(file, []byte("rotated-token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source.Invalidate("file-token")
+	if token, err := source.Token(ctx); err != nil || token != "rotated-token" {
+		t.Errorf("expected rotated-token, got %s, %v", token, err)
+	}
+
+	if _, err := NewFileTokenSource(filepath.Join(t.TempDir(), "missing")).Token(ctx); !errors.Is(err, ErrAPITokenUnavailable) {
+		t.Errorf("expected ErrAPITokenUnavailable, got %v", err)
+	}
+}
+
+func TestCommandTokenSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands need a POSIX shell")
+	}
+	ctx := context.Background()
+
+	if token, err := NewCommandTokenSource("echo '  command-token  '").Token(ctx); err != nil || token != "command-token" {
+		t.Errorf("expected command-token, got %s, %v", token, err)
+	}
+
+	_, err := NewCommandTokenSource("echo 'vault is sealed' >&2; exit 3").Token(ctx)
+	if !errors.Is(err, ErrAPITokenUnavailable) || !strings.Contains(err.Error(), "vault is sealed") {
+		t.Errorf("expected ErrAPITokenUnavailable with the command output, got %v", err)
+	}
+
+	if _, err := NewCommandTokenSource("true").Token(ctx); !errors.Is(err, ErrAPITokenUnavailable) {
+		t.Errorf("expected ErrAPITokenUnavailable for an empty output, got %v", err)
+	}
+}
+
+func TestClientCredentialsTokenSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "terraform" || clientSecret != "client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"error":"invalid_client","error_description":"bad credentials"}`)
+			return
+		}
+		if r.PostFormValue("grant_type") != "client_credentials" || r.PostFormValue("scope") != "read write" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"access_token":"oauth-token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	source := NewClientCredentialsTokenSource(ClientCredentials{
+		TokenURL:     server.URL,
+		ClientID:     "terraform",
+		ClientSecret: "client-secret",
+		Scopes:       []string{"read", "write"},
+	}, server.Client())
+	if token, err := source.Token(ctx); err != nil || token != "oauth-token" {
+		t.Fatalf("expected oauth-token, got %s, %v", token, err)
+	}
+	if source.expiry.IsZero() {
+		t.Error("expected the token to expire")
+	}
+
+	_, err := NewClientCredentialsTokenSource(ClientCredentials{TokenURL: server.URL, ClientID: "terraform", ClientSecret: "wrong"},
+		server.Client()).Token(ctx)
+	if !errors.Is(err, ErrAPITokenUnavailable) || !strings.Contains(err.Error(), "invalid_client") {
+		t.Errorf("expected ErrAPITokenUnavailable with the OAuth2 error, got %v", err)
+	}
+}
+
+func TestBearerTokenTransport(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if body, _ := io.ReadAll(r.Body); string(body) != `{"name":"service"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	post := func(source *TokenSource) int {
+		t.Helper()
+		client := &http.Client{Transport: &BearerTokenTransport{Transport: http.DefaultTransport, Source: source}}
+		resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name":"service"}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("renewed token", func(t *testing.T) {
+		authorizations = nil
+		source, _ := countingTokenSource(0)
+		if status := post(source); status != http.StatusAccepted {
+			t.Errorf("expected HTTP 202, got %d", status)
+		}
+		if len(authorizations) != 2 || authorizations[0] != "Bearer token-1" || authorizations[1] != "Bearer token-2" {
+			t.Errorf("expected the request to be sent again with the renewed token, got %v", authorizations)
+		}
+	})
+
+	t.Run("static token", func(t *testing.T) {
+		authorizations = nil
+		if status := post(NewStaticTokenSource("token-1")); status != http.StatusUnauthorized {
+			t.Errorf("expected HTTP 401, got %d", status)
+		}
+		if len(authorizations) != 1 {
+			t.Errorf("expected a single request, got %v", authorizations)
+		}
+	})
+}
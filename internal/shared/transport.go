@@ -0,0 +1,65 @@
+package shared
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// ErrInvalidProxyURL is returned by NewTransport when the proxy URL cannot be used.
+var ErrInvalidProxyURL = errors.New("invalid proxy URL")
+
+// TransportConfig holds the network settings of the HTTP clients used to call Solace Cloud.
+type TransportConfig struct {
+	// ProxyURL is the proxy all requests go through. When empty, the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment
+	// variables are used.
+	ProxyURL string
+	// CACertPEM holds additional root certificates trusted on top of the system ones, for example the root CA of a
+	// proxy inspecting TLS traffic.
+	CACertPEM []byte
+	// InsecureSkipVerify disables the verification of the server certificate. Only meant for local stand-ins.
+	InsecureSkipVerify bool
+}
+
+// NewTransport returns an HTTP transport with the default settings of Go, changed by the config.
+func NewTransport(config TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.ProxyURL != "" {
+		// The proxy URL may hold a password, it is left out of the errors or redacted.
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+			return nil, fmt.Errorf("%w: %w", ErrInvalidProxyURL, err)
+		}
+		if !slices.Contains([]string{"http", "https", "socks5"}, proxyURL.Scheme) || proxyURL.Host == "" {
+			return nil, fmt.Errorf("%w %q: expected http://, https:// or socks5:// followed by a host", ErrInvalidProxyURL, proxyURL.Redacted())
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(config.CACertPEM) > 0 || config.InsecureSkipVerify {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if len(config.CACertPEM) > 0 {
+			rootCAs, err := x509.SystemCertPool()
+			if err != nil {
+				rootCAs = x509.NewCertPool()
+			}
+			if !rootCAs.AppendCertsFromPEM(config.CACertPEM) {
+				return nil, errors.New("no PEM encoded certificate found in the CA certificates")
+			}
+			tlsConfig.RootCAs = rootCAs
+		}
+		tlsConfig.InsecureSkipVerify = config.InsecureSkipVerify
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
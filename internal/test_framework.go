@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"terraform-provider-solacecloud/missioncontrol"
 
 	"github.com/jarcoal/httpmock"
@@ -270,7 +271,7 @@ func CreateGetServiceResponse(params ConfigurableParams) string {
                 "name": "cluster-eks-eu-central-1a-3-62xaikge1vm",
                 "password": "3hg48osubca8t5blpqjcge0ju",
                 "remoteAddress": "mr-connection-80dx8er674q.messaging.solace.cloud",
-                "primaryRouterName": "` + determineRouterName(params.CustomRouterName) + `",
+                "primaryRouterName": "` + determineRouterName(params, "primary") + `",` + highAvailabilityRouterNames(params) + `
                 "supportedAuthenticationMode": [
                     "Basic"
                 ]
@@ -333,15 +334,25 @@ func CreateGetServiceResponse(params ConfigurableParams) string {
 
 }
 
-func determineRouterName(s string) string {
-	if s == "" {
-		return "6q1p55o6ovrprimary"
+func determineRouterName(params ConfigurableParams, role string) string {
+	if params.CustomRouterName == "" {
+		return "6q1p55o6ovr" + role
 	} else {
-		return s + "primarycn"
+		return params.CustomRouterName + role + "cn"
 
 	}
 }
 
+// highAvailabilityRouterNames adds the backup and monitoring router names of high availability service classes.
+func highAvailabilityRouterNames(params ConfigurableParams) string {
+	if !strings.HasSuffix(params.ServiceClass, "_HIGHAVAILABILITY") {
+		return ""
+	}
+	return `
+                "backupRouterName": "` + determineRouterName(params, "backup") + `",
+                "monitoringRouterName": "` + determineRouterName(params, "monitoring") + `",`
+}
+
 func (provider *TestInstance) GetBaseHcl() string {
 	if provider.mockedApi {
 		return `
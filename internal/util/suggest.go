@@ -0,0 +1,52 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ClosestMatch returns the candidate that is the fewest edits away from value, ignoring case, as long as it is close
+// enough to plausibly be a typo.
+func ClosestMatch(value string, candidates []string) (string, bool) {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := editDistance(strings.ToLower(value), strings.ToLower(candidate))
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if bestDistance < 0 || bestDistance > len(value)/3+1 {
+		return "", false
+	}
+	return best, true
+}
+
+// DidYouMean formats a suggestion for value to append to a diagnostic detail, empty when no candidate is close enough.
+func DidYouMean(value string, candidates []string) string {
+	if match, ok := ClosestMatch(value, candidates); ok {
+		return fmt.Sprintf(" Did you mean %q?", match)
+	}
+	return ""
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	ar, br := []rune(a), []rune(b)
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(br)]
+}
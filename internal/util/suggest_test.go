@@ -0,0 +1,32 @@
+package util
+
+import "testing"
+
+func TestClosestMatch(t *testing.T) {
+	candidates := []string{"aws-ca-central-1a", "aws-us-east-1a", "eks-us-east-1"}
+
+	tests := []struct {
+		value    string
+		expected string
+		found    bool
+	}{
+		{"aws-ca-centrl-1a", "aws-ca-central-1a", true},
+		{"AWS-US-EAST-1A", "aws-us-east-1a", true},
+		{"eks-us-east-1", "eks-us-east-1", true},
+		{"gke-europe-west", "", false},
+	}
+
+	for _, tt := range tests {
+		match, found := ClosestMatch(tt.value, candidates)
+		if match != tt.expected || found != tt.found {
+			t.Errorf("ClosestMatch(%q) = %q, %t; expected %q, %t", tt.value, match, found, tt.expected, tt.found)
+		}
+	}
+
+	if got := DidYouMean("DEVELOPPER", []string{"DEVELOPER"}); got != ` Did you mean "DEVELOPER"?` {
+		t.Errorf("unexpected suggestion: %s", got)
+	}
+	if got := DidYouMean("DEVELOPER", nil); got != "" {
+		t.Errorf("unexpected suggestion: %s", got)
+	}
+}
@@ -0,0 +1,44 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// tokenClaims are the claims of a Solace Cloud API token used by the provider.
+type tokenClaims struct {
+	Org string `json:"org"`
+	Sub string `json:"sub"`
+}
+
+// claimsFromToken decodes the claims of a JWT, without verifying it. It returns empty claims when the token is not a
+// JWT.
+func claimsFromToken(token string) tokenClaims {
+	var claims tokenClaims
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return claims
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return tokenClaims{}
+	}
+	return claims
+}
+
+// OrganizationIdFromToken returns the organization identifier carried in the "org" claim of a Solace Cloud API token,
+// or "" when the token is not a JWT with that claim. The token is not verified: the identifier is only used to address
+// organization level endpoints, which authenticate the token themselves.
+func OrganizationIdFromToken(token string) string {
+	return claimsFromToken(token).Org
+}
+
+// UserIdFromToken returns the identifier of the user who created a Solace Cloud API token, carried in its "sub"
+// claim, or "" when the token is not a JWT with that claim. Like OrganizationIdFromToken, the token is not verified.
+func UserIdFromToken(token string) string {
+	return claimsFromToken(token).Sub
+}
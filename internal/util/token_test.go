@@ -0,0 +1,37 @@
+package util
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestOrganizationIdFromToken(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"org":"myorg","orgType":"ENTERPRISE","sub":"user"}`))
+
+	tests := map[string]string{
+		"header." + payload + ".signature": "myorg",
+		"header.e30.signature":             "",
+		"header.not-base64!.signature":     "",
+		"not-a-jwt":                        "",
+	}
+	for token, expected := range tests {
+		if got := OrganizationIdFromToken(token); got != expected {
+			t.Errorf("OrganizationIdFromToken(%q) = %q, expected %q", token, got, expected)
+		}
+	}
+}
+
+func TestUserIdFromToken(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"org":"myorg","sub":"user-id"}`))
+
+	tests := map[string]string{
+		"header." + payload + ".signature": "user-id",
+		"header.e30.signature":             "",
+		"not-a-jwt":                        "",
+	}
+	for token, expected := range tests {
+		if got := UserIdFromToken(token); got != expected {
+			t.Errorf("UserIdFromToken(%q) = %q, expected %q", token, got, expected)
+		}
+	}
+}
@@ -1,7 +1,36 @@
 package util
 
-import "github.com/hashicorp/terraform-plugin-framework/attr"
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 func IsKnown(value attr.Value) bool {
 	return !value.IsNull() && !value.IsUnknown()
 }
+
+// EnumPointerValue converts a pointer to one of the API's string enums into a types.String, null when not set.
+func EnumPointerValue[T ~string](value *T) types.String {
+	if value == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(string(*value))
+}
+
+// Int32PointerValue converts a pointer to an API integer into a types.Int64, null when not set.
+func Int32PointerValue(value *int32) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
+
+// TimePointerValue converts a pointer to an API timestamp into an RFC 3339 types.String, null when not set.
+func TimePointerValue(value *time.Time) types.String {
+	if value == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(value.UTC().Format(time.RFC3339))
+}
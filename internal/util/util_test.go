@@ -0,0 +1,32 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type testState string
+
+func TestEnumPointerValue(t *testing.T) {
+	state := testState("COMPLETED")
+
+	if got := EnumPointerValue(&state); !got.Equal(types.StringValue("COMPLETED")) {
+		t.Errorf("Expected COMPLETED, got %s", got)
+	}
+	if got := EnumPointerValue[testState](nil); !got.IsNull() {
+		t.Errorf("Expected null, got %s", got)
+	}
+}
+
+func TestTimePointerValue(t *testing.T) {
+	created := time.Date(2025, 2, 19, 1, 18, 36, 0, time.FixedZone("EST", -5*60*60))
+
+	if got := TimePointerValue(&created); !got.Equal(types.StringValue("2025-02-19T06:18:36Z")) {
+		t.Errorf("Expected 2025-02-19T06:18:36Z, got %s", got)
+	}
+	if got := TimePointerValue(nil); !got.IsNull() {
+		t.Errorf("Expected null, got %s", got)
+	}
+}